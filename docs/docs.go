@@ -15,43 +15,84 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/bids/my": {
+        "/api/audit": {
             "get": {
-                "description": "Возвращает предложения, связанные с указанным пользователем",
+                "description": "Возвращает изменения тендеров и предложений организаций, за которые отвечает пользователь, от новых к старым",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Proposals"
+                    "Audit"
                 ],
-                "summary": "Получение предложений пользователя",
+                "summary": "Получение журнала аудита",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Имя пользователя",
+                        "description": "ID тендера, предложения или отзыва",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя, выполнившего изменение",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода в формате RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода в формате RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число событий (по умолчанию 5, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько событий пропустить",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
                         "name": "username",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список предложений пользователя",
+                        "description": "Список событий",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Proposal"
+                                "$ref": "#/definitions/models.AuditEvent"
                             }
                         }
                     },
                     "400": {
-                        "description": "Имя пользователя отсутствует",
+                        "description": "Неверные параметры фильтра или пагинации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при получении предложений",
+                        "description": "Ошибка при получении журнала аудита",
                         "schema": {
                             "type": "string"
                         }
@@ -59,9 +100,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/new": {
+        "/api/auth/login": {
             "post": {
-                "description": "Создает новое предложение от имени пользователя, проверяя принадлежность пользователя к организации",
+                "description": "Проверяет имя пользователя и пароль сотрудника и возвращает подписанный JWT",
                 "consumes": [
                     "application/json"
                 ],
@@ -69,35 +110,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Proposals"
+                    "Auth"
                 ],
-                "summary": "Создание предложения",
+                "summary": "Получение токена доступа",
                 "parameters": [
                     {
-                        "description": "Данные предложения",
-                        "name": "proposal",
+                        "description": "Имя пользователя и пароль",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Proposal"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Предложение успешно создано",
+                        "description": "Токен доступа",
                         "schema": {
-                            "$ref": "#/definitions/models.Proposal"
+                            "$ref": "#/definitions/models.TokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные или пользователь не принадлежит организации",
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверное имя пользователя или пароль",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при создании предложения",
+                        "description": "Ошибка сервиса",
                         "schema": {
                             "type": "string"
                         }
@@ -105,37 +152,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/status": {
-            "get": {
-                "description": "Возвращает текущий статус предложения",
+        "/api/auth/password": {
+            "put": {
+                "description": "Устанавливает новый пароль по текущему паролю или по одноразовому токену сброса, выданному владельцем организации сотрудника. Токен доступа не требуется",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Proposals"
+                    "Auth"
                 ],
-                "summary": "Получение статуса предложения",
+                "summary": "Смена пароля",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID предложения",
-                        "name": "bidId",
-                        "in": "query",
-                        "required": true
+                        "description": "Имя пользователя, текущий пароль или токен сброса и новый пароль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Текущий статус предложения",
+                        "description": "Пароль успешно изменен",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения",
+                        "description": "Неверный формат запроса или слишком короткий пароль",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверное имя пользователя, пароль или токен сброса",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при получении статуса предложения",
+                        "description": "Ошибка сервиса",
                         "schema": {
                             "type": "string"
                         }
@@ -143,37 +201,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/{bidId}/cancel": {
-            "put": {
-                "description": "Делает предложение видимым только автору и ответственным за организацию",
+        "/api/bids/my": {
+            "get": {
+                "description": "Возвращает предложения, связанные с указанным пользователем, включая содержимое еще не вскрытых запечатанных предложений",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Proposals"
                 ],
-                "summary": "Отмена предложения",
+                "summary": "Получение предложений пользователя",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID предложения",
-                        "name": "bidId",
-                        "in": "path",
-                        "required": true
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Предложение успешно отменено",
+                        "description": "Список предложений пользователя",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Proposal"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения",
+                        "description": "Имя пользователя отсутствует",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при отмене предложения",
+                        "description": "Ошибка при получении предложений",
                         "schema": {
                             "type": "string"
                         }
@@ -181,9 +244,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/{bidId}/edit": {
-            "patch": {
-                "description": "Редактирует предложение по указанному ID",
+        "/api/bids/new": {
+            "post": {
+                "description": "Создает новое предложение от имени пользователя, проверяя принадлежность пользователя к организации. Цена обязательна, если у тендера задан бюджет, и должна быть в его пределах и валюте; у предложений на аукцион цену задают только ставки аукциона. На приватный тендер предложения подают только приглашенные. Предложение на тендер с лотами указывает открытые лоты в lot_ids, и его цена проверяется по сумме их бюджетов. Содержимое запечатанного (sealed) предложения хранится зашифрованным до вскрытия предложений",
                 "consumes": [
                     "application/json"
                 ],
@@ -193,40 +256,63 @@ const docTemplate = `{
                 "tags": [
                     "Proposals"
                 ],
-                "summary": "Редактирование предложения",
+                "summary": "Создание предложения",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID предложения",
-                        "name": "bidId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления предложения",
+                        "description": "Данные предложения",
                         "name": "proposal",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "$ref": "#/definitions/models.Proposal"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности для безопасного повтора запроса",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновленное предложение",
+                        "description": "Предложение успешно создано",
                         "schema": {
                             "$ref": "#/definitions/models.Proposal"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения или некорректные данные",
+                        "description": "Неверные данные",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Срок приема предложений истек или запрос с этим ключом идемпотентности еще выполняется",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при редактировании предложения",
+                        "description": "Ошибка при создании предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -234,25 +320,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/{bidId}/publish": {
-            "put": {
-                "description": "Делает предложение доступным для ответственных за организацию и автора",
+        "/api/bids/status": {
+            "get": {
+                "description": "Возвращает текущий статус предложения",
                 "tags": [
                     "Proposals"
                 ],
-                "summary": "Публикация предложения",
+                "summary": "Получение статуса предложения",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "ID предложения",
                         "name": "bidId",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Предложение успешно опубликовано",
+                        "description": "Текущий статус предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -264,7 +350,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка при публикации предложения",
+                        "description": "Ошибка при получении статуса предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -272,16 +358,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/{bidId}/rollback/{version}": {
+        "/api/bids/{bidId}/acknowledge_amendment": {
             "put": {
-                "description": "Откатывает предложение к указанной версии по ID предложения",
+                "description": "Автор устаревшего предложения подтверждает, что предложение остается в силе для текущей версии тендера, без его редактирования. После этого предложение можно одобрить",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Proposals"
                 ],
-                "summary": "Откат версии предложения",
+                "summary": "Подтверждение изменений тендера",
                 "parameters": [
                     {
                         "type": "string",
@@ -291,28 +377,51 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Версия предложения",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Откатанное предложение",
+                        "description": "Предложение",
                         "schema": {
                             "$ref": "#/definitions/models.Proposal"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения или версия",
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложение не устарело, отменено или по нему принято решение",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при откате предложения",
+                        "description": "Ошибка при подтверждении изменений",
                         "schema": {
                             "type": "string"
                         }
@@ -320,106 +429,164 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bids/{tenderId}/list": {
+        "/api/bids/{bidId}/attachments": {
             "get": {
-                "description": "Возвращает список всех предложений, связанных с указанным тендером",
+                "description": "Возвращает последние ревизии файлов предложения в текущей или указанной версии предложения. Доступно ответственным организации-участника, а ответственным заказчика — после публикации предложения и вскрытия запечатанных предложений",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Proposals"
+                    "Attachments"
                 ],
-                "summary": "Получение предложений по тендеру",
+                "summary": "Получение файлов предложения",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия предложения",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список предложений для указанного тендера",
+                        "description": "Файлы предложения",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Proposal"
+                                "$ref": "#/definitions/models.Attachment"
                             }
                         }
                     },
                     "400": {
-                        "description": "Неверный ID тендера",
+                        "description": "Неверный ID предложения или версия",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка при получении предложений",
+                    "401": {
+                        "description": "Пользователь не указан",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/api/ping": {
-            "get": {
-                "description": "Возвращает \"ok\" если сервис работает",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Health Check"
-                ],
-                "summary": "Проверка состояния сервиса",
-                "responses": {
-                    "200": {
-                        "description": "ok",
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении файлов",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/api/tenders": {
-            "get": {
-                "description": "Возвращает список тендеров по переданному типу сервиса",
+            },
+            "post": {
+                "description": "Прикрепляет к предложению файл (коммерческое предложение, смету) по тем же правилам, что и к тендеру. Файлы можно менять, пока предложение не отменено и по нему не принято решение; файлы запечатанных предложений хранятся зашифрованными и меняются только до срока приема предложений",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Attachments"
                 ],
-                "summary": "Получить список тендеров",
+                "summary": "Загрузка файла предложения",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Тип сервиса",
-                        "name": "serviceType",
-                        "in": "query"
-                    }
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SHA-256 содержимого в hex",
+                        "name": "checksum_sha256",
+                        "in": "formData"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список тендеров",
+                        "description": "Загруженный файл",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Tender"
-                            }
+                            "$ref": "#/definitions/models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения, тип файла или контрольная сумма",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложение нельзя изменить",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервиса",
+                        "description": "Ошибка при загрузке файла",
                         "schema": {
                             "type": "string"
                         }
@@ -427,86 +594,147 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenders/my": {
+        "/api/bids/{bidId}/attachments/{attachmentId}": {
             "get": {
-                "description": "Возвращает список тендеров, созданных пользователем с указанным именем",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Отдает содержимое ревизии файла предложения, в том числе удаленной из текущей версии. ETag содержит SHA-256 содержимого. Доступ — как к списку файлов предложения",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Attachments"
                 ],
-                "summary": "Получить мои тендеры",
+                "summary": "Скачивание файла предложения",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Имя пользователя",
-                        "name": "username",
-                        "in": "query",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID файла",
+                        "name": "attachmentId",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список тендеров",
+                        "description": "Содержимое файла",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Tender"
-                            }
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения или файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или файл не найдены",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервиса",
+                        "description": "Ошибка при получении файла",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/api/tenders/new": {
-            "post": {
-                "description": "Создает новый тендер на основе переданных данных",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Удаляет последнюю ревизию файла из текущей версии предложения. В прежних версиях файл остается доступен",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Attachments"
                 ],
-                "summary": "Создать новый тендер",
+                "summary": "Удаление файла предложения",
                 "parameters": [
                     {
-                        "description": "Тендер",
-                        "name": "tender",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Tender"
-                        }
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID файла",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Созданный тендер",
+                        "description": "Удаленный файл",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.Attachment"
                         }
                     },
                     "400": {
-                        "description": "Ошибка валидации",
+                        "description": "Неверный ID предложения или файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или последняя ревизия файла не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложение нельзя изменить",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервиса",
+                        "description": "Ошибка при удалении файла",
                         "schema": {
                             "type": "string"
                         }
@@ -514,37 +742,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenders/status": {
-            "get": {
-                "description": "Возвращает текущий статус тендера",
+        "/api/bids/{bidId}/cancel": {
+            "put": {
+                "description": "Делает предложение видимым только автору и ответственным за организацию",
                 "tags": [
-                    "Tenders"
+                    "Proposals"
                 ],
-                "summary": "Получение статуса тендера",
+                "summary": "Отмена предложения",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID тендера",
-                        "name": "tenderId",
-                        "in": "query",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Текущий статус тендера",
+                        "description": "Предложение успешно отменено",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID тендера",
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при получении статуса тендера",
+                        "description": "Ошибка при отмене предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -552,9 +804,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenders/{tenderID}/edit": {
+        "/api/bids/{bidId}/edit": {
             "patch": {
-                "description": "Обновляет информацию о тендере по переданным данным и ID",
+                "description": "Редактирует предложение по указанному ID. Цена меняется, только если передана, и должна укладываться в бюджет тендера; цену предложения на аукцион меняют только ставки. Запечатанные (sealed) предложения можно редактировать только до срока приема предложений. Отредактированное предложение относится к текущей версии тендера и перестает быть устаревшим",
                 "consumes": [
                     "application/json"
                 ],
@@ -562,42 +814,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Proposals"
                 ],
-                "summary": "Редактировать тендер",
+                "summary": "Редактирование предложения",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID тендера",
-                        "name": "tenderID",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Обновленный тендер",
-                        "name": "updatedTender",
+                        "description": "Данные для обновления предложения",
+                        "name": "proposal",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.Proposal"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновленный тендер",
+                        "description": "Обновленное предложение",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.Proposal"
                         }
                     },
                     "400": {
-                        "description": "Ошибка валидации",
+                        "description": "Неверный ID предложения или некорректные данные",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Срок приема предложений на закрытый тендер истек",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервиса",
+                        "description": "Ошибка при редактировании предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -605,37 +887,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenders/{tenderId}/close": {
+        "/api/bids/{bidId}/feedback": {
             "put": {
-                "description": "Закрытие тендера, чтобы он стал недоступен для всех пользователей, кроме ответственных",
+                "description": "Отзыв оставляют ответственные за организацию тендера",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Tenders"
+                    "Proposals"
                 ],
-                "summary": "Закрытие тендера",
+                "summary": "Отзыв на предложение",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст отзыва",
+                        "name": "bidFeedback",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Тендер успешно закрыт",
+                        "description": "Оставленный отзыв",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ProposalFeedback"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID тендера",
+                        "description": "Неверный ID предложения или отзыв",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка при закрытии тендера",
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сохранении отзыва",
                         "schema": {
                             "type": "string"
                         }
@@ -643,37 +959,160 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenders/{tenderId}/publish": {
-            "put": {
-                "description": "Публикация тендера, чтобы он стал доступен всем пользователям",
+        "/api/bids/{bidId}/messages": {
+            "get": {
+                "description": "Возвращает сообщения переписки между заказчиком и организацией-участником, от новых к старым. Переписку видят только ответственные с правом переписки обеих сторон",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Tenders"
+                    "Messages"
                 ],
-                "summary": "Публикация тендера",
+                "summary": "Переписка по предложению",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число сообщений (по умолчанию 5, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько сообщений пропустить",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Тендер успешно опубликован",
+                        "description": "Сообщения",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProposalMessage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения или пагинация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не участвует в переписке",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении сообщений",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            },
+            "post": {
+                "description": "Отправляет сообщение в переписку между заказчиком и организацией-участником. Писать могут ответственные с правом переписки обеих сторон по опубликованному, не отмененному и не запечатанному предложению. Другая сторона получает уведомление bid.message_sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Отправка сообщения по предложению",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Текст сообщения",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SendMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сообщение",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProposalMessage"
+                        }
                     },
                     "400": {
-                        "description": "Неверный ID тендера",
+                        "description": "Неверный ID предложения или текст",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не участвует в переписке",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Переписка по предложению недоступна",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при публикации тендера",
+                        "description": "Ошибка при отправке сообщения",
                         "schema": {
                             "type": "string"
                         }
@@ -681,78 +1120,4515 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenders/{tenderId}/rollback/{version}": {
+        "/api/bids/{bidId}/messages/{messageId}/read": {
             "put": {
-                "description": "Откат тендера до определенной версии по его ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Отмечает прочитанными сообщение другой стороны и все более ранние непрочитанные сообщения этой стороны. Отправитель видит, когда и кем сообщение прочитано",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Messages"
                 ],
-                "summary": "Откатить тендер до указанной версии",
+                "summary": "Прочтение сообщения",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Версия тендера для отката",
-                        "name": "version",
+                        "type": "string",
+                        "description": "ID сообщения",
+                        "name": "messageId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Откатанный тендер",
+                        "description": "Прочитанное сообщение",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.ProposalMessage"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID тендера или версия",
+                        "description": "Неверный ID предложения или сообщения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не участвует в переписке",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или сообщение не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Сообщение отправлено своей стороной",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервиса",
+                        "description": "Ошибка при обновлении сообщения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/bids/{bidId}/publish": {
+            "put": {
+                "description": "Делает предложение доступным для ответственных за организацию и автора",
+                "tags": [
+                    "Proposals"
+                ],
+                "summary": "Публикация предложения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Предложение успешно опубликовано",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или тендер не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Срок приема предложений истек",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при публикации предложения",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Proposal": {
+        },
+        "/api/bids/{bidId}/rollback/{version}": {
+            "put": {
+                "description": "Откатывает предложение к указанной версии по ID предложения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposals"
+                ],
+                "summary": "Откат версии предложения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия предложения",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Откатанное предложение",
+                        "schema": {
+                            "$ref": "#/definitions/models.Proposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения или версия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при откате предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/bids/{bidId}/scores": {
+            "get": {
+                "description": "Возвращает оценки предложения всеми ответственными по всем критериям",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Получение оценок предложения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Оценки предложения",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProposalScore"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении оценок",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Ответственный за организацию тендера оценивает опубликованное предложение по критериям тендера от 0 до 10. Повторная оценка по критерию заменяет прежнюю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Оценка предложения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Оценки: ID критерия и оценка",
+                        "name": "scores",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProposalScore"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохраненные оценки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProposalScore"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения или оценки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложение не опубликовано или еще не вскрыто",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сохранении оценок",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/bids/{bidId}/submit_decision": {
+            "put": {
+                "description": "Решение принимают ответственные за организацию тендера. Одно отклонение отклоняет предложение; при согласовании кворумом min(3, число ответственных с правом решения) предложение согласуется, а тендер закрывается. Устаревшее предложение (поданное на прежнюю версию тендера) нельзя одобрить, пока автор не подтвердит изменения или не отредактирует его",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposals"
+                ],
+                "summary": "Отправка решения по предложению",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Решение: Approved или Rejected",
+                        "name": "decision",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Предложение после решения",
+                        "schema": {
+                            "$ref": "#/definitions/models.Proposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID предложения или решение",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложение не опубликовано или устарело, тендер закрыт или предложения еще не вскрыты",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сохранении решения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/bids/{tenderId}/list": {
+            "get": {
+                "description": "Возвращает список всех предложений, связанных с указанным тендером. Предложения без цены при сортировке по цене идут последними. Содержимое запечатанных (sealed) предложений скрыто до их вскрытия",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposals"
+                ],
+                "summary": "Получение предложений по тендеру",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: price_asc или price_desc (по умолчанию по дате создания)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список предложений для указанного тендера",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Proposal"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или порядок сортировки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/employees/new": {
+            "post": {
+                "description": "Создает сотрудника с уникальным именем пользователя и паролем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Регистрация сотрудника",
+                "parameters": [
+                    {
+                        "description": "Данные сотрудника",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterEmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Зарегистрированный сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Имя пользователя уже занято",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при регистрации сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/employees/{username}": {
+            "get": {
+                "description": "Возвращает сотрудника по имени пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Получение сотрудника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/employees/{username}/deactivate": {
+            "put": {
+                "description": "Деактивированный сотрудник не может войти и действовать от имени организаций. Доступно самому сотруднику и владельцам его организации",
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Деактивация сотрудника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сотрудник успешно деактивирован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден или уже деактивирован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при деактивации сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/employees/{username}/edit": {
+            "patch": {
+                "description": "Обновляет переданные имя и фамилию. Сотрудник может редактировать только себя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Редактирование сотрудника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые имя и фамилия",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленный сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден или деактивирован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при редактировании сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/employees/{username}/password_reset": {
+            "post": {
+                "description": "Выдает одноразовый токен, по которому сотрудник задает новый пароль через PUT /api/auth/password. Токен возвращается только один раз и заменяет выданный ранее. Доступно владельцам организации сотрудника и только с токеном, в том числе в режиме AUTH_MODE=compat; свой пароль сотрудник меняет по текущему паролю",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Сброс пароля сотрудника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Токен сброса пароля",
+                        "schema": {
+                            "$ref": "#/definitions/models.PasswordResetResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Запрос без токена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден или деактивирован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сбросе пароля",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/events/stream": {
+            "get": {
+                "description": "Держит соединение открытым и отправляет события, доступные пользователю: публикацию тендеров, смену статуса тендеров, в которых участвует организация пользователя, новые предложения на тендеры организации и решения по предложениям пользователя. Имя события — тип доменного события, данные — событие в JSON",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Поток событий",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "$ref": "#/definitions/models.DomainEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан или не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при открытии потока",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications": {
+            "get": {
+                "description": "Возвращает непрочитанные уведомления пользователя о публикации предложений на тендеры его организации, решениях и отзывах по его предложениям и закрытии тендеров, от новых к старым",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Получение уведомлений",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть также прочитанные уведомления",
+                        "name": "includeRead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число уведомлений (по умолчанию 5, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько уведомлений пропустить",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список уведомлений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры пагинации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан или не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении уведомлений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/preferences": {
+            "get": {
+                "description": "Возвращает для каждого типа событий, включены ли уведомления о нем. По умолчанию все уведомления включены",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Получение настроек уведомлений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Настройки уведомлений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NotificationPreference"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан или не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении настроек",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Включает или выключает уведомления о переданных типах событий; остальные настройки не меняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Изменение настроек уведомлений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Настройки уведомлений",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NotificationPreference"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Настройки уведомлений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NotificationPreference"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные настройки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан или не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сохранении настроек",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/read_all": {
+            "put": {
+                "description": "Отмечает все непрочитанные уведомления пользователя прочитанными",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Прочтение всех уведомлений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Уведомления отмечены прочитанными",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан или не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при обновлении уведомлений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{notificationId}/read": {
+            "put": {
+                "description": "Отмечает уведомление пользователя прочитанным",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Прочтение уведомления",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID уведомления",
+                        "name": "notificationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Прочитанное уведомление",
+                        "schema": {
+                            "$ref": "#/definitions/models.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID уведомления",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан или не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Уведомление не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при обновлении уведомления",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations": {
+            "get": {
+                "description": "Возвращает организации, отсортированные по названию, с пагинацией",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Получение списка организаций",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число организаций (по умолчанию 5, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько организаций пропустить",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список организаций",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Organization"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры пагинации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении организаций",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/new": {
+            "post": {
+                "description": "Создает организацию и назначает создателя ответственным за нее",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Создание организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Данные организации",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданная организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже является ответственным в другой организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при создании организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}": {
+            "get": {
+                "description": "Возвращает организацию по ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Получение организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Помечает организацию удаленной. Доступно только владельцам организации",
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Удаление организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Организация успешно удалена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при удалении организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/edit": {
+            "patch": {
+                "description": "Обновляет переданные поля организации. Доступно только владельцам организации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Редактирование организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Новые данные организации",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленная организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или данные организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при редактировании организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/responsibles": {
+            "get": {
+                "description": "Возвращает ответственных за организацию, их роли и кто выдал им доступ. Доступно только ответственным",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Получение ответственных за организацию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список ответственных",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrganizationResponsible"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении ответственных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/responsibles/new": {
+            "post": {
+                "description": "Назначает сотрудника ответственным с ролью (по умолчанию viewer). Пользователь может быть ответственным только в одной организации. Доступно только владельцам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Добавление ответственного",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Сотрудник",
+                        "name": "responsible",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddResponsibleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новый ответственный",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationResponsible"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации или данные",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Сотрудник уже является ответственным",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при добавлении ответственного",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/responsibles/{responsibleUsername}": {
+            "delete": {
+                "description": "Снимает сотрудника с ответственных. Последнего ответственного и последнего владельца удалить нельзя. Доступно только владельцам",
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Удаление ответственного",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя ответственного",
+                        "name": "responsibleUsername",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ответственный успешно удален",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не является ответственным за организацию",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Нельзя удалить последнего ответственного или владельца",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при удалении ответственного",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/responsibles/{responsibleUsername}/role": {
+            "put": {
+                "description": "Меняет роль ответственного: owner, tender_manager, bid_manager, reviewer или viewer. Последнего владельца понизить нельзя. Доступно только владельцам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Изменение роли ответственного",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя ответственного",
+                        "name": "responsibleUsername",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Новая роль",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ответственный с новой ролью",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationResponsible"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации или роль",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не является ответственным за организацию",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Нельзя понизить последнего владельца",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при изменении роли",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/webhooks": {
+            "get": {
+                "description": "Возвращает активные подписки организации без секретов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получение вебхуков организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список подписок",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении подписок",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/webhooks/new": {
+            "post": {
+                "description": "Подписывает организацию на события тендеров, в которых она участвует. Тело запроса подписывается HMAC-SHA256 с секретом подписки в заголовке X-Webhook-Signature. Секрет возвращается только при создании",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Создание вебхука",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Адрес, секрет и типы событий",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданная подписка",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные подписки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при создании подписки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/webhooks/{webhookId}": {
+            "delete": {
+                "description": "Прекращает доставку событий подписке; история доставок сохраняется",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Удаление вебхука",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подписки",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка успешно удалена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации или подписки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при удалении подписки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{organizationId}/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "description": "Возвращает доставки событий подписке от новых к старым: статус, число попыток, код ответа и последнюю ошибку. Доставки со статусом DEAD исчерпали все попытки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получение истории доставок вебхука",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID подписки",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус доставки (PENDING, DELIVERED, DEAD)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число доставок (по умолчанию 5, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько доставок пропустить",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список доставок",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении истории доставок",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ping": {
+            "get": {
+                "description": "Возвращает \"ok\" если сервис работает",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health Check"
+                ],
+                "summary": "Проверка состояния сервиса",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders": {
+            "get": {
+                "description": "Возвращает список тендеров по переданному типу сервиса. Приватные тендеры видны только ответственным заказчика и приглашенным",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Получить список тендеров",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип сервиса",
+                        "name": "serviceType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список тендеров",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tender"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервиса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/my": {
+            "get": {
+                "description": "Возвращает список тендеров, созданных пользователем с указанным именем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Получить мои тендеры",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список тендеров",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tender"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервиса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/new": {
+            "post": {
+                "description": "Создает новый тендер на основе переданных данных. Сроки приема предложений и принятия решения необязательны и должны быть в будущем; бюджет задается вместе с валютой. Если sealed = true, предложения запечатываются: их содержимое скрыто до срока приема предложений или ручного вскрытия, а срок решения должен быть позже срока приема. Тендер с kind = AUCTION проводится как аукцион на понижение: нужны budgetMax (начальная цена), minDecrement, roundDuration, snipingExtension и auctionStartsAt, сроки и запечатывание не допускаются. Тендер с visibility = PRIVATE видят и получают предложения только приглашенные организации и сотрудники; видимость задается при создании",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Создать новый тендер",
+                "parameters": [
+                    {
+                        "description": "Тендер",
+                        "name": "tender",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности для безопасного повтора запроса",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности еще выполняется",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервиса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/status": {
+            "get": {
+                "description": "Возвращает текущий статус тендера",
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Получение статуса тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Текущий статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении статуса тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderID}/edit": {
+            "patch": {
+                "description": "Обновляет информацию о тендере по переданным данным и ID. Сроки, бюджет и валюта меняются, только если переданы. Тип тендера и настройки аукциона не меняются; бюджет аукциона нельзя менять после его начала. Изменение опубликованного тендера создает новую версию, на которую должны перейти уже поданные предложения: их авторы получают уведомление bid.stale",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Редактировать тендер",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновленный тендер",
+                        "name": "updatedTender",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервиса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/attachments": {
+            "get": {
+                "description": "Возвращает последние ревизии файлов тендера в текущей или указанной версии тендера. Доступно всем, кто видит тендер",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Получение файлов тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия тендера",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файлы тендера",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или версия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении файлов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Прикрепляет к тендеру файл (спецификацию, чертеж). Принимаются документы, таблицы, изображения, чертежи и архивы (pdf, doc, docx, xls, xlsx, odt, ods, txt, csv, png, jpg, dwg, dxf, zip) не больше ATTACHMENT_MAX_SIZE. Файл с именем уже прикрепленного становится его новой ревизией в текущей версии тендера. Если передана checksum_sha256, она сверяется с содержимым",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Загрузка файла тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SHA-256 содержимого в hex",
+                        "name": "checksum_sha256",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Загруженный файл",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера, тип файла или контрольная сумма",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер закрыт",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при загрузке файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/attachments/{attachmentId}": {
+            "get": {
+                "description": "Отдает содержимое ревизии файла тендера, в том числе удаленной из текущей версии. ETag содержит SHA-256 содержимого. Доступно всем, кто видит тендер",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Скачивание файла тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID файла",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Содержимое файла",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или файл не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет последнюю ревизию файла из текущей версии тендера. В прежних версиях файл остается доступен",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Удаление файла тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID файла",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленный файл",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или последняя ревизия файла не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер закрыт",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при удалении файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/auction/bids": {
+            "post": {
+                "description": "Снижает цену опубликованного предложения на тендер-аукцион. Первая ставка не должна превышать начальную цену (budgetMax), каждая следующая должна быть ниже лучшей ставки не меньше чем на minDecrement. Ставки принимаются строго по очереди. Ставка за snipingExtension секунд до конца раунда продлевает раунд; аукцион заканчивается после раунда без ставок",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auctions"
+                ],
+                "summary": "Ставка аукциона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Ставка: proposal_id, price и необязательная currency",
+                        "name": "bid",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AuctionBid"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Принятая ставка",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuctionBid"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или данные ставки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или предложение не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Аукцион не идет или цена не ниже лучшей ставки на шаг",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при приеме ставки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/auction/leaderboard": {
+            "get": {
+                "description": "Возвращает текущий раунд, время его окончания, лучшую цену и опубликованные предложения, упорядоченные по их лучшей ставке. Состояние рассчитывается на момент запроса",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auctions"
+                ],
+                "summary": "Таблица лидеров аукциона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Таблица лидеров",
+                        "schema": {
+                            "$ref": "#/definitions/models.Leaderboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден или не является аукционом",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении таблицы лидеров",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/close": {
+            "put": {
+                "description": "Закрытие тендера, чтобы он стал недоступен для всех пользователей, кроме ответственных",
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Закрытие тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тендер успешно закрыт",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при закрытии тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/criteria": {
+            "get": {
+                "description": "Возвращает взвешенные критерии, по которым оцениваются предложения на тендер",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Получение критериев оценки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Критерии оценки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EvaluationCriterion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении критериев",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет критерии оценки тендера. Вес критерия — целое число от 1 до 100, итоговая оценка нормируется на сумму весов. Критерии нельзя менять после того, как предложения начали оценивать",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Задание критериев оценки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Критерии: название, тип (price, delivery_time, experience, custom) и вес",
+                        "name": "criteria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EvaluationCriterion"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Критерии оценки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EvaluationCriterion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или критерии",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер закрыт или предложения уже оценивались",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сохранении критериев",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/invitations": {
+            "get": {
+                "description": "Возвращает приглашения в приватный тендер, включая отозванные",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Получение приглашений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Приглашения",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderInvitation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер не приватный",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении приглашений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Приглашает в приватный тендер организацию (organization_id) или сотрудника (username). Приглашенный сотрудник и ответственные приглашенной организации видят тендер в списке и могут подавать на него предложения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Приглашение в приватный тендер",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Приглашаемая организация или сотрудник",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.InviteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Приглашение",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или данные",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер, организация или сотрудник не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер не приватный или приглашение уже есть",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при создании приглашения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/invitations/{invitationId}": {
+            "delete": {
+                "description": "Отзывает приглашение: тендер пропадает из списка приглашенного, и он больше не может подавать предложения. Уже поданные предложения сохраняются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Отзыв приглашения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID приглашения",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отозванное приглашение",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или приглашения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или действующее приглашение не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер не приватный",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при отзыве приглашения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/lots": {
+            "get": {
+                "description": "Возвращает лоты тендера с их статусом и присужденным предложением",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Получение лотов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Лоты тендера",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderLot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении лотов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет лоты тендера. У каждого лота свой тип услуг и бюджет в валюте тендера. Предложения на тендер с лотами подаются на один или несколько лотов, одобренному предложению присуждаются его лоты, а тендер закрывается, когда присуждены все лоты. Лоты нельзя менять после подачи первого предложения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Задание лотов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Лоты: название, описание, тип услуг и бюджет",
+                        "name": "lots",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderLot"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Лоты тендера",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderLot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или лоты",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер закрыт, является аукционом или на него уже поданы предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при сохранении лотов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/open_bids": {
+            "put": {
+                "description": "Расшифровывает и открывает ответственным содержимое запечатанных (sealed) предложений тендера до срока приема предложений. После вскрытия новые предложения не принимаются. По истечении срока приема предложения вскрываются автоматически",
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Вскрытие предложений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Предложения вскрыты",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложения тендера не запечатаны или уже вскрыты",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при вскрытии предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/publish": {
+            "put": {
+                "description": "Публикация тендера, чтобы он стал доступен всем пользователям",
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Публикация тендера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тендер успешно опубликован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при публикации тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/questions": {
+            "get": {
+                "description": "Возвращает вопросы с ответами по тендеру, старые первыми. Вопросы без ответа видят только ответственные заказчика и спросившей организации",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Вопросы по тендеру",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число вопросов",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вопросы",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderQuestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или пагинация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при получении вопросов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Задает вопрос по опубликованному тендеру от имени организации-участника до срока приема предложений. Спросившая организация и сотрудник не раскрываются: после ответа вопрос видят все, кому виден тендер",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Вопрос по тендеру",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Организация и текст вопроса",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AskQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вопрос",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderQuestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или данные",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер не принимает вопросы",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при создании вопроса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/questions/{questionId}/answer": {
+            "put": {
+                "description": "Отвечает на вопрос по опубликованному тендеру. Если ответ меняет условия тендера, в amendment_note описывается изменение, и ответ создает новую версию тендера. Участники тендера получают уведомление tender.question_answered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Ответ на вопрос",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID вопроса",
+                        "name": "questionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Ответ и описание изменения тендера",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AnswerQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вопрос с ответом",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderQuestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или данные",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или вопрос не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Вопрос уже отвечен или тендер не опубликован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при ответе на вопрос",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/ranking": {
+            "get": {
+                "description": "Возвращает опубликованные предложения, упорядоченные по взвешенной оценке: для каждого критерия берется средняя оценка ответственных, неоцененные критерии считаются нулем. При равной оценке выше предложение с меньшей ценой",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Рейтинг предложений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рейтинг предложений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RankingEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при расчете рейтинга",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenders/{tenderId}/rollback/{version}": {
+            "put": {
+                "description": "Откат тендера до определенной версии по его ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Откатить тендер до указанной версии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия тендера для отката",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя (если запрос без токена)",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Откатанный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или версия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не указан",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав в организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервиса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AddResponsibleRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnswerQuestionRequest": {
+            "type": "object",
+            "required": [
+                "answer"
+            ],
+            "properties": {
+                "amendment_note": {
+                    "type": "string"
+                },
+                "answer": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AskQuestionRequest": {
+            "type": "object",
+            "required": [
+                "organization_id",
+                "question"
+            ],
+            "properties": {
+                "organization_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Attachment": {
+            "type": "object",
+            "properties": {
+                "checksum_sha256": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_version": {
+                    "type": "integer"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "$ref": "#/definitions/models.AttachmentEntity"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "tender_id": {
+                    "type": "string"
+                },
+                "uploaded_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AttachmentEntity": {
+            "type": "string",
+            "enum": [
+                "tender",
+                "proposal"
+            ],
+            "x-enum-varnames": [
+                "AttachmentTender",
+                "AttachmentProposal"
+            ]
+        },
+        "models.AuctionBid": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "2400.00"
+                },
+                "proposal_id": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "tender_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChangeRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                }
+            }
+        },
+        "models.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventType"
+                    }
+                },
+                "secret": {
+                    "description": "Secret for HMAC signatures; generated if empty.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CriterionKind": {
+            "type": "string",
+            "enum": [
+                "price",
+                "delivery_time",
+                "experience",
+                "custom"
+            ],
+            "x-enum-varnames": [
+                "CriterionPrice",
+                "CriterionDeliveryTime",
+                "CriterionExperience",
+                "CriterionCustom"
+            ]
+        },
+        "models.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "DELIVERED",
+                "DEAD"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliveryDelivered",
+                "DeliveryDead"
+            ]
+        },
+        "models.DomainEvent": {
+            "type": "object",
+            "properties": {
+                "aggregate_id": {
+                    "type": "string"
+                },
+                "aggregate_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.EventType"
+                }
+            }
+        },
+        "models.Employee": {
+            "type": "object",
+            "required": [
+                "id",
+                "username"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deactivated_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EvaluationCriterion": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/models.CriterionKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tender_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Weight is the relative importance of the criterion; weights of a tender need not sum to 100.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EventType": {
+            "type": "string",
+            "enum": [
+                "tender.published",
+                "tender.closed",
+                "tender.bids_opened",
+                "bid.created",
+                "bid.published",
+                "bid.canceled",
+                "bid.approved",
+                "bid.rejected",
+                "bid.stale",
+                "bid.feedback_added",
+                "bid.message_sent",
+                "tender.auction_bid",
+                "tender.lot_awarded",
+                "tender.question_answered"
+            ],
+            "x-enum-varnames": [
+                "EventTenderPublished",
+                "EventTenderClosed",
+                "EventBidsOpened",
+                "EventBidCreated",
+                "EventBidPublished",
+                "EventBidCanceled",
+                "EventBidApproved",
+                "EventBidRejected",
+                "EventBidStale",
+                "EventFeedbackAdded",
+                "EventMessageSent",
+                "EventAuctionBid",
+                "EventLotAwarded",
+                "EventQuestionAnswered"
+            ]
+        },
+        "models.InviteRequest": {
+            "type": "object",
+            "properties": {
+                "organization_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "best_price": {
+                    "type": "string"
+                },
+                "ended": {
+                    "type": "boolean"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LeaderboardEntry"
+                    }
+                },
+                "last_sequence": {
+                    "description": "LastSequence is the sequence number of the last accepted bid.",
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "round_bids": {
+                    "type": "integer"
+                },
+                "round_ends_at": {
+                    "type": "string"
+                },
+                "tender_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "bid_at": {
+                    "type": "string"
+                },
+                "bids": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "proposal_id": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LotStatus": {
+            "type": "string",
+            "enum": [
+                "OPEN",
+                "AWARDED"
+            ],
+            "x-enum-varnames": [
+                "LotOpen",
+                "LotAwarded"
+            ]
+        },
+        "models.MessageSide": {
+            "type": "string",
+            "enum": [
+                "TENDER",
+                "BIDDER"
+            ],
+            "x-enum-varnames": [
+                "SideTender",
+                "SideBidder"
+            ]
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/models.EventType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "read_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NotificationPreference": {
+            "type": "object",
+            "required": [
+                "event_type"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/models.EventType"
+                }
+            }
+        },
+        "models.Organization": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.OrganizationType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationResponsible": {
+            "type": "object",
+            "required": [
+                "id",
+                "organization_id",
+                "user_id"
+            ],
+            "properties": {
+                "granted_at": {
+                    "type": "string"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationType": {
+            "type": "string",
+            "enum": [
+                "IE",
+                "LLC",
+                "JSC"
+            ],
+            "x-enum-varnames": [
+                "IE",
+                "LLC",
+                "JSC"
+            ]
+        },
+        "models.PasswordResetResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "reset_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Proposal": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "id",
+                "organization_id",
+                "status",
+                "tender_id",
+                "title",
+                "version"
+            ],
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lot_ids": {
+                    "description": "LotIDs are the lots the bid is for; bids on tenders with lots must name at least one.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "2500.00"
+                },
+                "sealed": {
+                    "type": "boolean"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tender_id": {
+                    "type": "string"
+                },
+                "tender_version": {
+                    "description": "TenderVersion is the version of the tender the bid was submitted or last revised against. The bid\nis stale once the tender is amended past it and can't be approved until the author acknowledges\nthe amendment or revises the bid.",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProposalFeedback": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "proposal_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProposalMessage": {
             "type": "object",
-            "required": [
-                "author_id",
-                "id",
-                "organization_id",
-                "status",
-                "tender_id",
-                "title",
-                "version"
-            ],
             "properties": {
                 "author_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "body": {
                     "type": "string"
                 },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
@@ -761,20 +5637,140 @@ const docTemplate = `{
                 "organization_id": {
                     "type": "string"
                 },
-                "status": {
+                "proposal_id": {
                     "type": "string"
                 },
-                "tender_id": {
+                "read_at": {
                     "type": "string"
                 },
-                "title": {
+                "read_by": {
+                    "type": "string"
+                },
+                "sender_side": {
+                    "$ref": "#/definitions/models.MessageSide"
+                }
+            }
+        },
+        "models.ProposalScore": {
+            "type": "object",
+            "properties": {
+                "author_id": {
                     "type": "string"
                 },
+                "criterion_id": {
+                    "type": "string"
+                },
+                "proposal_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "models.RankingEntry": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
                 },
-                "version": {
+                "organization_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "proposal_id": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "scored_criteria": {
+                    "description": "ScoredCriteria is the number of criteria the proposal has at least one score by.",
+                    "type": "integer"
+                },
+                "scorers": {
                     "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RegisterEmployeeRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResponsibleRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "tender_manager",
+                "bid_manager",
+                "reviewer",
+                "viewer"
+            ],
+            "x-enum-varnames": [
+                "RoleOwner",
+                "RoleTenderManager",
+                "RoleBidManager",
+                "RoleReviewer",
+                "RoleViewer"
+            ]
+        },
+        "models.SendMessageRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "username"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                },
+                "reset_token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
@@ -790,27 +5786,78 @@ const docTemplate = `{
                 "version"
             ],
             "properties": {
+                "auctionStartsAt": {
+                    "type": "string"
+                },
+                "bidsOpenedAt": {
+                    "type": "string"
+                },
+                "budgetMax": {
+                    "type": "string",
+                    "example": "5000.00"
+                },
+                "budgetMin": {
+                    "description": "BudgetMin and BudgetMax bound the price of bids; Currency is required when either is set.",
+                    "type": "string",
+                    "example": "1000.00"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "creatorUsername": {
                     "type": "string"
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "decisionDeadline": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "kind": {
+                    "description": "An AUCTION tender runs a reverse auction starting at AuctionStartsAt from BudgetMax: each bid must\nundercut the best one by at least MinDecrement. RoundDuration and SnipingExtension are in seconds.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TenderKind"
+                        }
+                    ],
+                    "example": "STANDARD"
+                },
+                "minDecrement": {
+                    "type": "string",
+                    "example": "100.00"
+                },
                 "organizationId": {
                     "type": "string"
                 },
+                "roundDuration": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "sealed": {
+                    "description": "Bids on a Sealed tender are stored encrypted and hidden from everyone but their authors\nuntil BidsOpenedAt, which is set at the submission deadline or when the bids are opened manually.",
+                    "type": "boolean"
+                },
                 "serviceType": {
                     "type": "string"
                 },
+                "snipingExtension": {
+                    "type": "integer",
+                    "example": 60
+                },
                 "status": {
                     "type": "string"
                 },
+                "submissionDeadline": {
+                    "description": "Bids are accepted until SubmissionDeadline; the tender is closed automatically at\nDecisionDeadline or, if it is not set, at SubmissionDeadline.",
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 },
@@ -819,6 +5866,219 @@ const docTemplate = `{
                 },
                 "version": {
                     "type": "integer"
+                },
+                "visibility": {
+                    "description": "A PRIVATE tender is visible only to the responsibles of its organization and to invitees.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TenderVisibility"
+                        }
+                    ],
+                    "example": "PUBLIC"
+                }
+            }
+        },
+        "models.TenderInvitation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "tender_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderKind": {
+            "type": "string",
+            "enum": [
+                "STANDARD",
+                "AUCTION"
+            ],
+            "x-enum-varnames": [
+                "TenderStandard",
+                "TenderAuction"
+            ]
+        },
+        "models.TenderLot": {
+            "type": "object",
+            "properties": {
+                "awarded_at": {
+                    "type": "string"
+                },
+                "awarded_proposal_id": {
+                    "type": "string"
+                },
+                "budget_max": {
+                    "type": "string",
+                    "example": "5000.00"
+                },
+                "budget_min": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "service_type": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LotStatus"
+                },
+                "tender_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderQuestion": {
+            "type": "object",
+            "properties": {
+                "amendment_note": {
+                    "type": "string"
+                },
+                "amendment_version": {
+                    "type": "integer"
+                },
+                "answer": {
+                    "type": "string"
+                },
+                "answered_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "tender_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderVisibility": {
+            "type": "string",
+            "enum": [
+                "PUBLIC",
+                "PRIVATE"
+            ],
+            "x-enum-varnames": [
+                "VisibilityPublic",
+                "VisibilityPrivate"
+            ]
+        },
+        "models.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/models.EventType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.DeliveryStatus"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
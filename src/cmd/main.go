@@ -6,7 +6,9 @@ package main
 */
 
 import (
+	"context"
 	"database/sql"
+	"expvar"
 	"fmt"
 	"github.com/joho/godotenv"
 	"log"
@@ -18,11 +20,19 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 
 	_ "github.com/jackc/pgx/stdlib"
 
+	"avito_2024/src/internal/auth"
 	"avito_2024/src/internal/delivery/middleware"
+	"avito_2024/src/internal/events"
+	"avito_2024/src/internal/ratelimit"
 	"avito_2024/src/internal/repository/postgresql"
+	"avito_2024/src/internal/scheduler"
+	"avito_2024/src/internal/sealing"
+	"avito_2024/src/internal/storage"
+	"avito_2024/src/internal/tracing"
 
 	hand "avito_2024/src/internal/delivery/http"
 
@@ -32,6 +42,8 @@ import (
 	_ "avito_2024/docs"
 )
 
+const serviceName = "avito_2024"
+
 // @title API Avito
 // @version 1.0
 // @description API server for Avito
@@ -46,12 +58,30 @@ func main() {
 		log.Fatalf("Error loading .env file")
 	}
 
+	shutdownTracing := initializeTracing()
+	defer shutdownTracing()
+
 	db := initializeDatabase()
 	defer db.Close()
 
 	migrateDatabase(db)
 
-	router := setupRouter(db)
+	stopDispatcher := startEventDispatcher(db)
+	defer stopDispatcher()
+
+	stopWebhookWorker := startWebhookWorker(db)
+	defer stopWebhookWorker()
+
+	stopDeadlineScheduler := startDeadlineScheduler(db)
+	defer stopDeadlineScheduler()
+
+	stopIdempotencyCleaner := startIdempotencyCleaner(db)
+	defer stopIdempotencyCleaner()
+
+	hub, stopEventStream := startEventStream(db)
+	defer stopEventStream()
+
+	router := setupRouter(db, hub)
 	startServer(router)
 }
 
@@ -65,8 +95,25 @@ func setLocalTime() {
 	time.Local = local
 }
 
-// initializeDatabase database initialization.
-func initializeDatabase() *sql.DB {
+// initializeTracing tracer provider initialization.
+func initializeTracing() func() {
+	shutdown, err := tracing.InitTracerProvider(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdown(ctx); err != nil {
+			log.Println("Error with shutting down tracing.", err)
+		}
+	}
+}
+
+// databaseDSN database connection string.
+func databaseDSN() string {
 	username := os.Getenv("POSTGRES_USERNAME")
 	password := os.Getenv("POSTGRES_PASSWORD")
 	host := os.Getenv("POSTGRES_HOST")
@@ -74,9 +121,13 @@ func initializeDatabase() *sql.DB {
 	database := os.Getenv("POSTGRES_DATABASE")
 	sslmode := "disable"
 
-	dsn := fmt.Sprintf("user=%s dbname=%s password=%s host=%s port=%s sslmode=%s",
+	return fmt.Sprintf("user=%s dbname=%s password=%s host=%s port=%s sslmode=%s",
 		username, database, password, host, port, sslmode)
-	db, err := sql.Open("pgx", dsn)
+}
+
+// initializeDatabase database initialization.
+func initializeDatabase() *sql.DB {
+	db, err := sql.Open("pgx", databaseDSN())
 	if err != nil {
 		log.Fatalf("Can't parse config: %v", err)
 	}
@@ -102,54 +153,426 @@ func migrateDatabase(db *sql.DB) {
 	}
 }
 
-func initializeTender(db *sql.DB) *hand.TenderHandler {
+// startEventDispatcher starts delivering outbox events in the background and returns the function that stops it.
+// Besides the configured publisher, events are queued for the organizations' webhooks and employees' notifications.
+func startEventDispatcher(db *sql.DB) func() {
+	publisher, err := events.NewPublisherFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize event publisher: %v", err)
+	}
+
+	interval := durationFromEnv("EVENTS_DISPATCH_INTERVAL", time.Second)
+
+	outboxRepository := postgresql.NewOutboxRepository(sqlx.NewDb(db, "pqx"))
+	webhookRepository := postgresql.NewWebhookRepository(sqlx.NewDb(db, "pqx"))
+	notificationRepository := postgresql.NewNotificationRepository(sqlx.NewDb(db, "pqx"))
+	fanout := events.MultiPublisher{
+		publisher,
+		events.NewWebhookFanout(webhookRepository),
+		events.NewNotificationFanout(notificationRepository),
+	}
+	maxAttempts, err := strconv.Atoi(os.Getenv("EVENTS_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts < 1 {
+		maxAttempts = 10
+	}
+
+	policy := events.RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   durationFromEnv("EVENTS_RETRY_BASE_DELAY", 5*time.Second),
+		MaxDelay:    durationFromEnv("EVENTS_RETRY_MAX_DELAY", 10*time.Minute),
+	}
+
+	dispatcher := events.NewDispatcher(outboxRepository, fanout, policy, 100, interval)
+
+	return runInBackground(dispatcher.Run)
+}
+
+// startWebhookWorker starts delivering queued webhooks in the background and returns the function that stops it.
+func startWebhookWorker(db *sql.DB) func() {
+	maxAttempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts < 1 {
+		maxAttempts = 8
+	}
+
+	policy := events.RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   durationFromEnv("WEBHOOK_RETRY_BASE_DELAY", 10*time.Second),
+		MaxDelay:    durationFromEnv("WEBHOOK_RETRY_MAX_DELAY", time.Hour),
+	}
+
+	webhookRepository := postgresql.NewWebhookRepository(sqlx.NewDb(db, "pqx"))
+	worker := events.NewWebhookWorker(webhookRepository, policy, durationFromEnv("WEBHOOK_TIMEOUT", 10*time.Second), 20, time.Second)
+
+	return runInBackground(worker.Run)
+}
+
+// startDeadlineScheduler starts closing tenders with passed deadlines in the background and returns the function that stops it.
+func startDeadlineScheduler(db *sql.DB) func() {
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+	opener := initializeOpener(db, initializeSealer())
+	deadlineScheduler := scheduler.NewDeadlineScheduler(tenderRepository, opener, 100, durationFromEnv("TENDER_DEADLINE_CHECK_INTERVAL", 30*time.Second))
+
+	return runInBackground(deadlineScheduler.Run)
+}
+
+// startEventStream listens to the events announced by all service instances and returns the hub
+// that fans them out to the connected event streams.
+func startEventStream(db *sql.DB) (*events.StreamHub, func()) {
+	listener, err := postgresql.NewListener(databaseDSN(), "outbox_event")
+	if err != nil {
+		log.Fatalf("Failed to initialize event listener: %v", err)
+	}
+
+	outboxRepository := postgresql.NewOutboxRepository(sqlx.NewDb(db, "pqx"))
+	hub := events.NewStreamHub(outboxRepository)
+
+	stop := runInBackground(func(ctx context.Context) {
+		listener.Run(ctx, func(payload string) {
+			hub.Notify(ctx, payload)
+		})
+	})
+
+	return hub, stop
+}
+
+// startIdempotencyCleaner starts deleting expired idempotency keys in the background and returns the function that stops it.
+func startIdempotencyCleaner(db *sql.DB) func() {
+	idempotencyRepository := postgresql.NewIdempotencyRepository(sqlx.NewDb(db, "pqx"))
+	cleaner := scheduler.NewIdempotencyCleaner(idempotencyRepository, 1000, durationFromEnv("IDEMPOTENCY_CLEANUP_INTERVAL", 10*time.Minute))
+
+	return runInBackground(cleaner.Run)
+}
+
+// runInBackground runs the function in a goroutine and returns the function that cancels it and waits for it to return.
+func runInBackground(run func(ctx context.Context)) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
+
+func initializeAuthorizer(db *sql.DB) *hand.Authorizer {
+	responsibleRepository := postgresql.NewOrganizationRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewAuthorizer(responsibleRepository)
+}
+
+func initializeSealer() *sealing.Sealer {
+	key := os.Getenv("BID_ENCRYPTION_KEY")
+	if key == "" {
+		log.Fatalf("BID_ENCRYPTION_KEY is not set")
+	}
+
+	sealer, err := sealing.NewSealerFromBase64(key)
+	if err != nil {
+		log.Fatalf("Invalid BID_ENCRYPTION_KEY: %v", err)
+	}
+
+	return sealer
+}
+
+func initializeOpener(db *sql.DB, sealer *sealing.Sealer) *sealing.Opener {
 	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+	proposalRepository := postgresql.NewProposalRepository(sqlx.NewDb(db, "pqx"))
 
-	return hand.NewTenderHandler(tenderRepository)
+	return sealing.NewOpener(tenderRepository, proposalRepository, sealer)
 }
 
-func initializeProposal(db *sql.DB) *hand.ProposalHandler {
+func initializeTender(db *sql.DB, sealer *sealing.Sealer, authorizer *hand.Authorizer) *hand.TenderHandler {
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewTenderHandler(tenderRepository, initializeOpener(db, sealer), authorizer)
+}
+
+func initializeProposal(db *sql.DB, sealer *sealing.Sealer, authorizer *hand.Authorizer) *hand.ProposalHandler {
 	proposalRepository := postgresql.NewProposalRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+
+	lotRepository := postgresql.NewLotRepository(sqlx.NewDb(db, "pqx"))
 
-	return hand.NewProposalHandler(proposalRepository)
+	return hand.NewProposalHandler(proposalRepository, tenderRepository, lotRepository, sealer, authorizer)
 }
 
-func setupRouter(db *sql.DB) http.Handler {
+func initializeEvaluation(db *sql.DB, authorizer *hand.Authorizer) *hand.EvaluationHandler {
+	evaluationRepository := postgresql.NewEvaluationRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+	proposalRepository := postgresql.NewProposalRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewEvaluationHandler(evaluationRepository, tenderRepository, proposalRepository, authorizer)
+}
+
+func initializeLot(db *sql.DB, authorizer *hand.Authorizer) *hand.LotHandler {
+	lotRepository := postgresql.NewLotRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewLotHandler(lotRepository, tenderRepository, authorizer)
+}
+
+func initializeInvitation(db *sql.DB, authorizer *hand.Authorizer) *hand.InvitationHandler {
+	invitationRepository := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewInvitationHandler(invitationRepository, tenderRepository, authorizer)
+}
+
+func initializeQuestion(db *sql.DB, authorizer *hand.Authorizer) *hand.QuestionHandler {
+	questionRepository := postgresql.NewQuestionRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewQuestionHandler(questionRepository, tenderRepository, authorizer)
+}
+
+func initializeMessage(db *sql.DB, authorizer *hand.Authorizer) *hand.MessageHandler {
+	messageRepository := postgresql.NewMessageRepository(sqlx.NewDb(db, "pqx"))
+	proposalRepository := postgresql.NewProposalRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewMessageHandler(messageRepository, proposalRepository, tenderRepository, authorizer)
+}
+
+func initializeAttachment(db *sql.DB, sealer *sealing.Sealer, authorizer *hand.Authorizer) *hand.AttachmentHandler {
+	blobs, err := storage.NewStorageFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize attachment storage: %v", err)
+	}
+
+	maxSize, err := strconv.ParseInt(os.Getenv("ATTACHMENT_MAX_SIZE"), 10, 64)
+	if err != nil || maxSize < 1 {
+		maxSize = 20 << 20
+	}
+
+	attachmentRepository := postgresql.NewAttachmentRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+	proposalRepository := postgresql.NewProposalRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewAttachmentHandler(attachmentRepository, tenderRepository, proposalRepository, blobs, sealer, authorizer, maxSize)
+}
+
+func initializeAuction(db *sql.DB, authorizer *hand.Authorizer) *hand.AuctionHandler {
+	auctionRepository := postgresql.NewAuctionRepository(sqlx.NewDb(db, "pqx"))
+	tenderRepository := postgresql.NewTenderRepository(sqlx.NewDb(db, "pqx"))
+	proposalRepository := postgresql.NewProposalRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewAuctionHandler(auctionRepository, tenderRepository, proposalRepository, authorizer)
+}
+
+func initializeOrganization(db *sql.DB, authorizer *hand.Authorizer) *hand.OrganizationHandler {
+	organizationRepository := postgresql.NewOrganizationRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewOrganizationHandler(organizationRepository, authorizer)
+}
+
+func initializeEmployee(db *sql.DB) *hand.EmployeeHandler {
+	employeeRepository := postgresql.NewEmployeeRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewEmployeeHandler(employeeRepository, durationFromEnv("AUTH_PASSWORD_RESET_TTL", time.Hour))
+}
+
+func initializeWebhook(db *sql.DB, authorizer *hand.Authorizer) *hand.WebhookHandler {
+	webhookRepository := postgresql.NewWebhookRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewWebhookHandler(webhookRepository, authorizer)
+}
+
+func initializeStream(db *sql.DB, hub *events.StreamHub) *hand.StreamHandler {
+	employeeRepository := postgresql.NewEmployeeRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewStreamHandler(hub, employeeRepository)
+}
+
+func initializeNotification(db *sql.DB) *hand.NotificationHandler {
+	notificationRepository := postgresql.NewNotificationRepository(sqlx.NewDb(db, "pqx"))
+	employeeRepository := postgresql.NewEmployeeRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewNotificationHandler(notificationRepository, employeeRepository)
+}
+
+func initializeAudit(db *sql.DB) *hand.AuditHandler {
+	auditRepository := postgresql.NewAuditRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewAuditHandler(auditRepository)
+}
+
+func initializeTokenManager() *auth.TokenManager {
+	secret := os.Getenv("AUTH_JWT_SECRET")
+	if secret == "" {
+		log.Fatalf("AUTH_JWT_SECRET is not set")
+	}
+
+	ttl, err := time.ParseDuration(os.Getenv("AUTH_TOKEN_TTL"))
+	if err != nil {
+		ttl = 24 * time.Hour
+	}
+
+	return auth.NewTokenManager(secret, ttl)
+}
+
+func initializeAuth(db *sql.DB, tokens *auth.TokenManager) *hand.AuthHandler {
+	authRepository := postgresql.NewAuthRepository(sqlx.NewDb(db, "pqx"))
+
+	return hand.NewAuthHandler(authRepository, tokens)
+}
+
+func initializeIdempotency(db *sql.DB) mux.MiddlewareFunc {
+	idempotencyRepository := postgresql.NewIdempotencyRepository(sqlx.NewDb(db, "pqx"))
+
+	config := middleware.IdempotencyConfig{
+		TTL:         durationFromEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		LockTimeout: durationFromEnv("IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+	}
+
+	return middleware.Idempotency(idempotencyRepository, config)
+}
+
+func initializeRateLimit() mux.MiddlewareFunc {
+	config := middleware.RateLimitConfig{
+		Reads:      ratelimit.LoadLimit("RATE_LIMIT_READ", ratelimit.Limit{Rate: 20, Burst: 40}),
+		Writes:     ratelimit.LoadLimit("RATE_LIMIT_WRITE", ratelimit.Limit{Rate: 5, Burst: 10}),
+		TrustProxy: os.Getenv("RATE_LIMIT_TRUST_PROXY") == "true",
+	}
+
+	return middleware.RateLimit(ratelimit.NewMemoryStore(), config)
+}
+
+func setupRouter(db *sql.DB, hub *events.StreamHub) http.Handler {
 	router := mux.NewRouter()
 
-	tender := setupTenderRouter(db)
+	tender := setupTenderRouter(db, hub)
 	router.PathPrefix("/api").Handler(tender)
 
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
+	router.Handle("/debug/vars", expvar.Handler())
 
-	return middleware.RequestLogger(router)
+	return middleware.RequestID(middleware.RequestLogger(middleware.Recovery(router)))
 }
 
-func setupTenderRouter(db *sql.DB) http.Handler {
+func setupTenderRouter(db *sql.DB, hub *events.StreamHub) http.Handler {
 	router := mux.NewRouter().PathPrefix("/api").Subrouter()
-
-	tenderHandler := initializeTender(db)
-	proposalHandler := initializeProposal(db)
+	tokens := initializeTokenManager()
+	authMode := auth.ParseMode(os.Getenv("AUTH_MODE"))
+	authRepository := postgresql.NewAuthRepository(sqlx.NewDb(db, "pqx"))
+
+	router.Use(otelmux.Middleware(serviceName))
+	router.Use(middleware.Authenticate(tokens, authRepository, authMode, "/api/ping", "/api/auth/login", "/api/auth/password", "/api/employees/new"))
+	router.Use(initializeRateLimit())
+	router.Use(middleware.AuditActor)
+
+	authorizer := initializeAuthorizer(db)
+	sealer := initializeSealer()
+	tenderHandler := initializeTender(db, sealer, authorizer)
+	proposalHandler := initializeProposal(db, sealer, authorizer)
+	evaluationHandler := initializeEvaluation(db, authorizer)
+	auctionHandler := initializeAuction(db, authorizer)
+	lotHandler := initializeLot(db, authorizer)
+	invitationHandler := initializeInvitation(db, authorizer)
+	questionHandler := initializeQuestion(db, authorizer)
+	attachmentHandler := initializeAttachment(db, sealer, authorizer)
+	messageHandler := initializeMessage(db, authorizer)
+	organizationHandler := initializeOrganization(db, authorizer)
+	employeeHandler := initializeEmployee(db)
+	authHandler := initializeAuth(db, tokens)
+	auditHandler := initializeAudit(db)
+	webhookHandler := initializeWebhook(db, authorizer)
+	streamHandler := initializeStream(db, hub)
+	notificationHandler := initializeNotification(db)
+	idempotency := initializeIdempotency(db)
 
 	router.HandleFunc("/ping", tenderHandler.Ping).Methods("GET", "OPTIONS")
-	router.HandleFunc("/tenders/new", tenderHandler.CreateTender).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/login", authHandler.Login).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/password", authHandler.SetPassword).Methods("PUT", "OPTIONS")
+	router.Handle("/tenders/new", idempotency(http.HandlerFunc(tenderHandler.CreateTender))).Methods("POST", "OPTIONS")
 	router.HandleFunc("/tenders/{tenderId}/edit", tenderHandler.EditTender).Methods("PATCH", "OPTIONS")
 	router.HandleFunc("/tenders/my", tenderHandler.GetMyTenders).Methods("GET", "OPTIONS")
 	router.HandleFunc("/tenders", tenderHandler.GetTenders).Methods("GET", "OPTIONS")
 	router.HandleFunc("/tenders/{tenderId}/rollback/{version}", tenderHandler.RollbackTender).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tenders/{tenderId}/publish", tenderHandler.PublishTender).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tenders/{tenderId}/close", tenderHandler.CloseTender).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/open_bids", tenderHandler.OpenBids).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tenders/status", tenderHandler.GetTenderStatus).Methods("GET", "OPTIONS")
-
-	router.HandleFunc("/bids/new", proposalHandler.CreateProposal).Methods("POST", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/criteria", evaluationHandler.GetCriteria).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/criteria", evaluationHandler.SetCriteria).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/ranking", evaluationHandler.GetRanking).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/lots", lotHandler.GetLots).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/lots", lotHandler.SetLots).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/invitations", invitationHandler.CreateInvitation).Methods("POST", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/invitations", invitationHandler.GetInvitations).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/invitations/{invitationId}", invitationHandler.RevokeInvitation).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/questions", questionHandler.AskQuestion).Methods("POST", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/questions", questionHandler.GetQuestions).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/questions/{questionId}/answer", questionHandler.AnswerQuestion).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/attachments", attachmentHandler.UploadTenderAttachment).Methods("POST", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/attachments", attachmentHandler.GetTenderAttachments).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/attachments/{attachmentId}", attachmentHandler.DownloadTenderAttachment).Methods("GET", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/attachments/{attachmentId}", attachmentHandler.DeleteTenderAttachment).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/auction/bids", auctionHandler.PlaceBid).Methods("POST", "OPTIONS")
+	router.HandleFunc("/tenders/{tenderId}/auction/leaderboard", auctionHandler.GetLeaderboard).Methods("GET", "OPTIONS")
+
+	router.Handle("/bids/new", idempotency(http.HandlerFunc(proposalHandler.CreateProposal))).Methods("POST", "OPTIONS")
 	router.HandleFunc("/bids/my", proposalHandler.GetMyProposals).Methods("GET", "OPTIONS")
 	router.HandleFunc("/bids/{tenderId}/list", proposalHandler.GetProposalsByTender).Methods("GET", "OPTIONS")
 	router.HandleFunc("/bids/{bidId}/edit", proposalHandler.EditProposal).Methods("PATCH", "OPTIONS")
 	router.HandleFunc("/bids/{bidId}/rollback/{version}", proposalHandler.RollbackProposal).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/bids/{bidId}/publish", proposalHandler.PublishProposal).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/bids/{bidId}/cancel", proposalHandler.CancelProposal).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/acknowledge_amendment", proposalHandler.AcknowledgeAmendment).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/submit_decision", proposalHandler.SubmitDecision).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/feedback", proposalHandler.LeaveFeedback).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/scores", evaluationHandler.GetProposalScores).Methods("GET", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/scores", evaluationHandler.ScoreProposal).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/attachments", attachmentHandler.UploadProposalAttachment).Methods("POST", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/attachments", attachmentHandler.GetProposalAttachments).Methods("GET", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/attachments/{attachmentId}", attachmentHandler.DownloadProposalAttachment).Methods("GET", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/attachments/{attachmentId}", attachmentHandler.DeleteProposalAttachment).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/messages", messageHandler.SendMessage).Methods("POST", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/messages", messageHandler.GetMessages).Methods("GET", "OPTIONS")
+	router.HandleFunc("/bids/{bidId}/messages/{messageId}/read", messageHandler.MarkMessageRead).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/bids/status", proposalHandler.GetProposalStatus).Methods("GET", "OPTIONS")
 
+	router.HandleFunc("/organizations/new", organizationHandler.CreateOrganization).Methods("POST", "OPTIONS")
+	router.HandleFunc("/organizations", organizationHandler.GetOrganizations).Methods("GET", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}", organizationHandler.GetOrganization).Methods("GET", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/edit", organizationHandler.EditOrganization).Methods("PATCH", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}", organizationHandler.DeleteOrganization).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/responsibles", organizationHandler.GetResponsibles).Methods("GET", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/responsibles/new", organizationHandler.AddResponsible).Methods("POST", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/responsibles/{responsibleUsername}", organizationHandler.RemoveResponsible).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/responsibles/{responsibleUsername}/role", organizationHandler.ChangeResponsibleRole).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/webhooks/new", webhookHandler.CreateWebhook).Methods("POST", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/webhooks", webhookHandler.GetWebhooks).Methods("GET", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/webhooks/{webhookId}", webhookHandler.DeleteWebhook).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/organizations/{organizationId}/webhooks/{webhookId}/deliveries", webhookHandler.GetWebhookDeliveries).Methods("GET", "OPTIONS")
+
+	router.HandleFunc("/employees/new", employeeHandler.RegisterEmployee).Methods("POST", "OPTIONS")
+	router.HandleFunc("/employees/{username}", employeeHandler.GetEmployee).Methods("GET", "OPTIONS")
+	router.HandleFunc("/employees/{username}/edit", employeeHandler.EditEmployee).Methods("PATCH", "OPTIONS")
+	router.HandleFunc("/employees/{username}/deactivate", employeeHandler.DeactivateEmployee).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/employees/{username}/password_reset", employeeHandler.ResetPassword).Methods("POST", "OPTIONS")
+
+	router.HandleFunc("/audit", auditHandler.GetAuditEvents).Methods("GET", "OPTIONS")
+	router.HandleFunc("/events/stream", streamHandler.StreamEvents).Methods("GET", "OPTIONS")
+
+	router.HandleFunc("/notifications", notificationHandler.GetNotifications).Methods("GET", "OPTIONS")
+	router.HandleFunc("/notifications/read_all", notificationHandler.MarkAllNotificationsRead).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/notifications/preferences", notificationHandler.GetNotificationPreferences).Methods("GET", "OPTIONS")
+	router.HandleFunc("/notifications/preferences", notificationHandler.SetNotificationPreferences).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/notifications/{notificationId}/read", notificationHandler.MarkNotificationRead).Methods("PUT", "OPTIONS")
+
 	return router
 }
 
@@ -158,8 +581,8 @@ func startServer(router http.Handler) {
 		AllowedOrigins:   []string{"http://127.0.0.1:5000", "http://localhost:5000", "http://localhost:8080"},
 		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut, http.MethodOptions},
 		AllowCredentials: true,
-		AllowedHeaders:   []string{"X-Csrf-Token", "Content-Type", "AuthToken"},
-		ExposedHeaders:   []string{"X-Csrf-Token", "AuthToken"},
+		AllowedHeaders:   []string{"X-Csrf-Token", "Content-Type", "AuthToken", "Authorization", "Traceparent", "Tracestate", "X-Request-ID", "Idempotency-Key"},
+		ExposedHeaders:   []string{"X-Csrf-Token", "AuthToken", "X-Request-ID", "Retry-After", "Idempotent-Replayed", "Content-Disposition", "ETag"},
 	})
 
 	corsHandler := c.Handler(router)
@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- У существующих сотрудников пароля нет: войти они смогут после сброса пароля владельцем организации
+ALTER TABLE employee ADD COLUMN password_hash VARCHAR(100);
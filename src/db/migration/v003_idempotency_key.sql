@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE idempotency_key (
+    key VARCHAR(255) PRIMARY KEY,
+    request_hash VARCHAR(64) NOT NULL,
+    response_status INT,
+    response_content_type VARCHAR(100),
+    response_body BYTEA,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- +migrate Up
+ALTER TABLE organization ADD COLUMN deleted_at TIMESTAMP;
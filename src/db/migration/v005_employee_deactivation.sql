@@ -0,0 +1,2 @@
+-- +migrate Up
+ALTER TABLE employee ADD COLUMN deactivated_at TIMESTAMP;
@@ -0,0 +1,27 @@
+-- +migrate Up
+-- В начальных данных init.sql сотрудник dwhite отвечает за две организации,
+-- а v006 разрешает пользователю быть ответственным только в одной. Назначение
+-- в Innovative Education JSC передается новому сотруднику ejones. Строка
+-- меняется, только если она осталась такой, какой ее создал init.sql. Имя файла
+-- выбрано так, чтобы миграция выполнялась перед v006.
+INSERT INTO employee (id, username, first_name, last_name, created_at, updated_at)
+SELECT '9d1c7e2a-3f4b-4c5d-8e6f-7a8b9c0d1e2f', 'ejones', 'Emily', 'Jones', '2024-09-10 11:10:00', '2024-09-10 11:10:00'
+WHERE EXISTS (
+    SELECT 1
+    FROM organization_responsible
+    WHERE id = '13ff3a93-6eae-41cb-9f1e-947182ab3e1f'
+        AND organization_id = 'b6b22d5f-18b3-4ee2-ae88-3db31e98bb7d'
+        AND user_id = '75a2a0eb-1a8b-4b1c-bb68-ccab04547c4e'
+)
+ON CONFLICT DO NOTHING;
+
+UPDATE organization_responsible
+SET user_id = '9d1c7e2a-3f4b-4c5d-8e6f-7a8b9c0d1e2f'
+WHERE id = '13ff3a93-6eae-41cb-9f1e-947182ab3e1f'
+    AND organization_id = 'b6b22d5f-18b3-4ee2-ae88-3db31e98bb7d'
+    AND user_id = '75a2a0eb-1a8b-4b1c-bb68-ccab04547c4e'
+    AND EXISTS (
+        SELECT 1
+        FROM employee
+        WHERE id = '9d1c7e2a-3f4b-4c5d-8e6f-7a8b9c0d1e2f' AND username = 'ejones'
+    );
@@ -0,0 +1,32 @@
+-- +migrate Up
+ALTER TABLE organization_responsible
+    ADD COLUMN granted_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    ADD COLUMN granted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+-- Пользователь может быть ответственным только в одной организации.
+-- Миграция не выбирает, какую из организаций оставить: если пользователь
+-- отвечает за несколько, она останавливается, и лишние назначения
+-- удаляет оператор.
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(user_id::TEXT, ', ' ORDER BY user_id)
+    INTO duplicates
+    FROM (
+        SELECT user_id
+        FROM organization_responsible
+        GROUP BY user_id
+        HAVING COUNT(*) > 1
+    ) duplicate_users;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'employees are responsible for several organizations: %', duplicates
+            USING HINT = 'keep one organization_responsible row per user_id and rerun the migration';
+    END IF;
+END;
+$$;
+-- +migrate StatementEnd
+
+ALTER TABLE organization_responsible ADD CONSTRAINT organization_responsible_user_id_key UNIQUE (user_id);
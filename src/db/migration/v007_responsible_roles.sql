@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TYPE responsible_role AS ENUM (
+    'owner',
+    'tender_manager',
+    'bid_manager',
+    'reviewer',
+    'viewer'
+);
+
+-- Существующие ответственные сохраняют все права.
+ALTER TABLE organization_responsible ADD COLUMN role responsible_role NOT NULL DEFAULT 'owner';
+
+CREATE TABLE proposal_decision (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    proposal_id UUID REFERENCES proposal(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    decision VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (proposal_id, author_id)
+);
+
+CREATE TABLE proposal_feedback (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    proposal_id UUID REFERENCES proposal(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- Доменные события записываются в одной транзакции с изменением и затем доставляются диспетчером.
+CREATE TABLE outbox_event (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    organization_id UUID,
+    payload JSONB NOT NULL,
+    request_id VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX outbox_event_pending_idx ON outbox_event (created_at) WHERE published_at IS NULL;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- Уведомляет все экземпляры сервиса о новых событиях для потока /api/events/stream.
+-- +migrate StatementBegin
+CREATE FUNCTION outbox_event_notify() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('outbox_event', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER outbox_event_notify
+    AFTER INSERT ON outbox_event
+    FOR EACH ROW EXECUTE FUNCTION outbox_event_notify();
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE notification (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (employee_id, event_id)
+);
+
+CREATE INDEX notification_unread_idx ON notification (employee_id, created_at) WHERE read_at IS NULL;
+
+-- Отсутствие записи означает, что уведомления о событии включены.
+CREATE TABLE notification_preference (
+    employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (employee_id, event_type)
+);
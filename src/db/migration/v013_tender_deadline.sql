@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Предложения принимаются до submission_deadline, тендер закрывается автоматически
+-- в decision_deadline. Тендер без decision_deadline автоматически не закрывается.
+ALTER TABLE tender
+    ADD COLUMN submission_deadline TIMESTAMP,
+    ADD COLUMN decision_deadline TIMESTAMP,
+    ADD CONSTRAINT tender_deadline_order_check
+        CHECK (decision_deadline IS NULL OR submission_deadline IS NULL OR decision_deadline >= submission_deadline);
+
+CREATE INDEX tender_deadline_idx ON tender (decision_deadline) WHERE status = 'PUBLISHED';
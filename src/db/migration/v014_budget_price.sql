@@ -0,0 +1,17 @@
+-- +migrate Up
+-- Суммы хранятся в NUMERIC с двумя знаками после запятой, валюта — код ISO 4217.
+ALTER TABLE tender
+    ADD COLUMN budget_min NUMERIC(15, 2) CHECK (budget_min >= 0),
+    ADD COLUMN budget_max NUMERIC(15, 2) CHECK (budget_max >= 0),
+    ADD COLUMN currency VARCHAR(3),
+    ADD CONSTRAINT tender_budget_order_check
+        CHECK (budget_min IS NULL OR budget_max IS NULL OR budget_max >= budget_min),
+    ADD CONSTRAINT tender_budget_currency_check
+        CHECK ((budget_min IS NULL AND budget_max IS NULL) OR currency IS NOT NULL);
+
+ALTER TABLE proposal
+    ADD COLUMN price NUMERIC(15, 2) CHECK (price >= 0),
+    ADD COLUMN currency VARCHAR(3),
+    ADD CONSTRAINT proposal_price_currency_check CHECK (price IS NULL OR currency IS NOT NULL);
+
+CREATE INDEX proposal_tender_price_idx ON proposal (tender_id, price) WHERE status = 'PUBLISHED';
@@ -0,0 +1,24 @@
+-- +migrate Up
+-- Взвешенные критерии оценки предложений на тендер.
+CREATE TABLE tender_criterion (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('price', 'delivery_time', 'experience', 'custom')),
+    weight INT NOT NULL CHECK (weight > 0),
+    position INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, name)
+);
+
+-- Оценка предложения ответственным по одному критерию.
+CREATE TABLE proposal_score (
+    proposal_id UUID REFERENCES proposal(id) ON DELETE CASCADE,
+    criterion_id UUID REFERENCES tender_criterion(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES employee(id),
+    score INT NOT NULL CHECK (score BETWEEN 0 AND 10),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (proposal_id, criterion_id, author_id)
+);
+
+CREATE INDEX proposal_score_criterion_idx ON proposal_score (criterion_id);
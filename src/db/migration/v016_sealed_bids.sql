@@ -0,0 +1,12 @@
+-- +migrate Up
+-- Содержимое запечатанных предложений хранится зашифрованным в sealed_content до вскрытия
+-- (bids_opened_at), открытые поля предложения в это время пустые.
+ALTER TABLE tender
+    ADD COLUMN sealed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN bids_opened_at TIMESTAMP;
+
+ALTER TABLE proposal
+    ADD COLUMN sealed_content BYTEA,
+    ADD COLUMN sealed BOOLEAN GENERATED ALWAYS AS (sealed_content IS NOT NULL) STORED;
+
+CREATE INDEX tender_sealed_deadline_idx ON tender (submission_deadline) WHERE sealed AND bids_opened_at IS NULL;
@@ -0,0 +1,38 @@
+-- +migrate Up
+-- Аукцион на понижение: участники снижают цену своих предложений по раундам длительностью
+-- round_duration секунд. Ставка за sniping_extension секунд до конца раунда продлевает раунд,
+-- аукцион заканчивается после раунда без ставок. Начальная цена — budget_max.
+ALTER TABLE tender
+    ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'STANDARD' CHECK (kind IN ('STANDARD', 'AUCTION')),
+    ADD COLUMN min_decrement NUMERIC(15, 2) CHECK (min_decrement > 0),
+    ADD COLUMN round_duration INT CHECK (round_duration > 0),
+    ADD COLUMN sniping_extension INT CHECK (sniping_extension >= 0),
+    ADD COLUMN auction_starts_at TIMESTAMP,
+    ADD CONSTRAINT tender_auction_settings_check
+        CHECK (kind <> 'AUCTION' OR (min_decrement IS NOT NULL AND round_duration IS NOT NULL
+            AND sniping_extension IS NOT NULL AND auction_starts_at IS NOT NULL AND budget_max IS NOT NULL));
+
+-- Текущее состояние аукциона; строка обновляется при каждой ставке под блокировкой тендера.
+CREATE TABLE auction (
+    tender_id UUID PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    round INT NOT NULL DEFAULT 1,
+    round_ends_at TIMESTAMP NOT NULL,
+    round_bids INT NOT NULL DEFAULT 0,
+    best_price NUMERIC(15, 2),
+    last_sequence BIGINT NOT NULL DEFAULT 0
+);
+
+-- Ставки аукциона в порядке их принятия (sequence).
+CREATE TABLE auction_bid (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL,
+    proposal_id UUID NOT NULL REFERENCES proposal(id) ON DELETE CASCADE,
+    round INT NOT NULL,
+    price NUMERIC(15, 2) NOT NULL CHECK (price >= 0),
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, sequence)
+);
+
+CREATE INDEX auction_bid_proposal_idx ON auction_bid (proposal_id);
@@ -0,0 +1,24 @@
+-- +migrate Up
+-- Лоты — самостоятельные части тендера со своим типом услуг и бюджетом в валюте тендера.
+-- Лот присуждается одобренному предложению, тендер закрывается, когда присуждены все лоты.
+CREATE TABLE tender_lot (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    service_type VARCHAR(50) NOT NULL,
+    budget_min NUMERIC(15, 2) CHECK (budget_min >= 0),
+    budget_max NUMERIC(15, 2) CHECK (budget_max >= 0),
+    position INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'AWARDED')),
+    awarded_proposal_id UUID REFERENCES proposal(id),
+    awarded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, title),
+    CONSTRAINT tender_lot_budget_order_check
+        CHECK (budget_min IS NULL OR budget_max IS NULL OR budget_max >= budget_min)
+);
+
+-- Лоты, на которые подано предложение; у предложений на тендер без лотов список пуст.
+ALTER TABLE proposal
+    ADD COLUMN lot_ids UUID[] NOT NULL DEFAULT '{}';
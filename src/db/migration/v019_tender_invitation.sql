@@ -0,0 +1,49 @@
+-- +migrate Up
+-- Приватные (PRIVATE) тендеры видят только ответственные заказчика и приглашенные сотрудники
+-- и организации. Отозванные приглашения сохраняются с revoked_at.
+ALTER TABLE tender
+    ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'PUBLIC' CHECK (visibility IN ('PUBLIC', 'PRIVATE'));
+
+CREATE TABLE tender_invitation (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    invited_by UUID REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP,
+    CONSTRAINT tender_invitation_invitee_check CHECK ((organization_id IS NULL) <> (employee_id IS NULL))
+);
+
+CREATE UNIQUE INDEX tender_invitation_organization_idx ON tender_invitation (tender_id, organization_id)
+    WHERE organization_id IS NOT NULL AND revoked_at IS NULL;
+CREATE UNIQUE INDEX tender_invitation_employee_idx ON tender_invitation (tender_id, employee_id)
+    WHERE employee_id IS NOT NULL AND revoked_at IS NULL;
+
+-- Видит ли сотрудник тендер: публичный тендер видят все, приватный — ответственные заказчика,
+-- приглашенные сотрудники и ответственные приглашенных организаций.
+-- +migrate StatementBegin
+CREATE FUNCTION tender_visible_to(p_tender_id UUID, p_username VARCHAR) RETURNS BOOLEAN AS $$
+    SELECT t.visibility = 'PUBLIC' OR EXISTS (
+        SELECT 1
+        FROM employee e
+        WHERE e.username = p_username AND (
+            EXISTS (
+                SELECT 1
+                FROM organization_responsible org_res
+                WHERE org_res.user_id = e.id AND org_res.organization_id = t.organization_id
+            )
+            OR EXISTS (
+                SELECT 1
+                FROM tender_invitation i
+                WHERE i.tender_id = t.id AND i.revoked_at IS NULL AND (
+                    i.employee_id = e.id
+                    OR i.organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = e.id)
+                )
+            )
+        )
+    )
+    FROM tender t
+    WHERE t.id = p_tender_id;
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
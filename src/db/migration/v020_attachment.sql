@@ -0,0 +1,26 @@
+-- +migrate Up
+-- Файлы тендеров и предложений. Содержимое хранится в блоб-хранилище под storage_key, в таблице —
+-- метаданные и SHA-256. Повторная загрузка файла с тем же именем создает новую ревизию; version —
+-- версия тендера или предложения на момент загрузки, deleted_version — версия, в которой файл удален.
+-- Файлы запечатанных предложений хранятся зашифрованными (sealed).
+CREATE TABLE attachment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('tender', 'proposal')),
+    entity_id UUID NOT NULL,
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    checksum_sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    sealed BOOLEAN NOT NULL DEFAULT FALSE,
+    version INT NOT NULL,
+    revision INT NOT NULL,
+    uploaded_by UUID REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_version INT,
+    deleted_at TIMESTAMP,
+    UNIQUE (entity_type, entity_id, file_name, revision)
+);
+
+CREATE INDEX attachment_entity_idx ON attachment (entity_type, entity_id);
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- Вопросы участников по тендеру. Спросившие организация и сотрудник хранятся для журнала аудита,
+-- но не раскрываются: ответы видят все, кому виден тендер. Если ответ меняет условия тендера,
+-- amendment_version — созданная ответом версия тендера, amendment_note — описание изменения.
+CREATE TABLE tender_question (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    asked_by UUID REFERENCES employee(id),
+    question TEXT NOT NULL,
+    answer TEXT,
+    answered_by UUID REFERENCES employee(id),
+    answered_at TIMESTAMP,
+    amendment_version INT,
+    amendment_note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX tender_question_tender_idx ON tender_question (tender_id, created_at);
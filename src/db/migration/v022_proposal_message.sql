@@ -0,0 +1,16 @@
+-- +migrate Up
+-- Переписка по предложению между заказчиком (TENDER) и организацией-участником (BIDDER).
+-- read_at и read_by — когда и кем из другой стороны сообщение прочитано.
+CREATE TABLE proposal_message (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    proposal_id UUID NOT NULL REFERENCES proposal(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    sender_side VARCHAR(20) NOT NULL CHECK (sender_side IN ('TENDER', 'BIDDER')),
+    author_id UUID REFERENCES employee(id),
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP,
+    read_by UUID REFERENCES employee(id)
+);
+
+CREATE INDEX proposal_message_proposal_idx ON proposal_message (proposal_id, created_at);
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- Версия тендера, на которую подано предложение. Предложение устаревает, когда тендер изменяется
+-- после подачи (tender_version меньше версии тендера), и не может быть одобрено, пока автор
+-- не подтвердит изменение или не отредактирует предложение.
+ALTER TABLE proposal
+    ADD COLUMN tender_version INT;
+
+UPDATE proposal p
+SET tender_version = t.version
+FROM tender t
+WHERE t.id = p.tender_id;
+
+ALTER TABLE proposal
+    ALTER COLUMN tender_version SET NOT NULL;
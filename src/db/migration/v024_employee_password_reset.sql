@@ -0,0 +1,5 @@
+-- +migrate Up
+-- Хранится только SHA-256 одноразового токена сброса пароля
+ALTER TABLE employee
+    ADD COLUMN password_reset_hash VARCHAR(64),
+    ADD COLUMN password_reset_expires_at TIMESTAMP;
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- reservation_id отличает текущую попытку от прежней, если ключ был занят заново;
+-- незавершенный ключ с истекшим locked_until и любой ключ с истекшим expires_at можно занять снова
+ALTER TABLE idempotency_key
+    ADD COLUMN reservation_id UUID,
+    ADD COLUMN locked_until TIMESTAMP,
+    ADD COLUMN expires_at TIMESTAMP;
+
+UPDATE idempotency_key
+SET reservation_id = uuid_generate_v4(),
+    expires_at = COALESCE(completed_at, created_at) + INTERVAL '24 hours';
+
+ALTER TABLE idempotency_key
+    ALTER COLUMN reservation_id SET NOT NULL,
+    ALTER COLUMN expires_at SET NOT NULL;
+
+CREATE INDEX idempotency_key_expires_at_idx ON idempotency_key (expires_at);
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- Ключ идемпотентности действует в пределах вызывающего пользователя: одинаковые ключи разных
+-- пользователей не пересекаются, и чужой сохраненный ответ не возвращается
+ALTER TABLE idempotency_key ADD COLUMN owner VARCHAR(50) NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_key DROP CONSTRAINT idempotency_key_pkey;
+ALTER TABLE idempotency_key ADD PRIMARY KEY (owner, key);
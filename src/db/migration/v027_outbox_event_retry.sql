@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Неудачная доставка события повторяется с экспоненциальной задержкой (next_attempt_at);
+-- после исчерпания попыток событие получает dead_at и больше не доставляется и не задерживает другие
+ALTER TABLE outbox_event
+    ADD COLUMN next_attempt_at TIMESTAMP,
+    ADD COLUMN dead_at TIMESTAMP;
+
+DROP INDEX outbox_event_pending_idx;
+CREATE INDEX outbox_event_pending_idx ON outbox_event (created_at) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX outbox_event_pending_aggregate_idx ON outbox_event (aggregate_id, created_at) WHERE published_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,3 @@
+-- +migrate Up
+-- Версия входит в выданные токены: смена пароля увеличивает ее, и прежние токены перестают действовать
+ALTER TABLE employee ADD COLUMN token_version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,7 @@
+-- Пароли тестовых сотрудников из init.sql для локальной разработки.
+-- Не является миграцией и не применяется автоматически:
+-- docker compose exec -T db psql -U postgres -d Avito < src/db/seed/dev.sql
+UPDATE employee
+SET password_hash = crypt('password', gen_salt('bf'))
+WHERE password_hash IS NULL
+    AND username IN ('jsmith', 'abrown', 'mjohnson', 'klang', 'dwhite', 'ejones');
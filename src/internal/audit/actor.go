@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"context"
+)
+
+type actorKey struct{}
+
+// WithActor stores the username of the employee performing the request, as recorded in audit events.
+func WithActor(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, actorKey{}, username)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Identity is the authenticated caller of a request. TokenVersion is the version of the employee's
+// credentials the token was issued for.
+type Identity struct {
+	UserID       uuid.UUID
+	Username     string
+	TokenVersion int
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller identity placed by the auth middleware.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
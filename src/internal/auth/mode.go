@@ -0,0 +1,20 @@
+package auth
+
+// Mode controls what happens to requests without a token.
+type Mode string
+
+const (
+	// ModeCompat lets anonymous requests through; handlers then trust the username
+	// from the query or body, as the original API specification expects.
+	ModeCompat Mode = "compat"
+	// ModeStrict rejects anonymous requests to protected routes with 401.
+	ModeStrict Mode = "strict"
+)
+
+func ParseMode(value string) Mode {
+	if Mode(value) == ModeStrict {
+		return ModeStrict
+	}
+
+	return ModeCompat
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const issuer = "avito_2024"
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type claims struct {
+	Username string `json:"username"`
+	Version  int    `json:"ver"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager issues and verifies HS256-signed JWTs for employees.
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(secret string, ttl time.Duration) *TokenManager {
+	return &TokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+func (m *TokenManager) Issue(identity Identity) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Username: identity.Username,
+		Version:  identity.TokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   identity.UserID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to sign token")
+	}
+
+	return signed, expiresAt, nil
+}
+
+// Verify checks the signature and expiry of the token. Whether the employee is still active and the
+// token version is current is checked against the database by the caller.
+func (m *TokenManager) Verify(tokenString string) (Identity, error) {
+	var tokenClaims claims
+	_, err := jwt.ParseWithClaims(tokenString, &tokenClaims, func(token *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Identity{}, errors.Wrap(ErrInvalidToken, err.Error())
+	}
+
+	userID, err := uuid.Parse(tokenClaims.Subject)
+	if err != nil {
+		return Identity{}, errors.Wrap(ErrInvalidToken, "invalid subject")
+	}
+
+	return Identity{UserID: userID, Username: tokenClaims.Username, TokenVersion: tokenClaims.Version}, nil
+}
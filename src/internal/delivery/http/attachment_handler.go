@@ -0,0 +1,660 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+	"avito_2024/src/internal/sealing"
+	"avito_2024/src/internal/storage"
+)
+
+const (
+	maxFileNameLength = 255
+	// multipartOverhead is the room left in the request body for the multipart headers and the other fields.
+	multipartOverhead = 64 << 10
+	// multipartMemory is the part of the uploaded file kept in memory; the rest is buffered on disk.
+	multipartMemory = 8 << 20
+)
+
+type AttachmentHandler struct {
+	AttachmentRepo _interface.AttachmentRepository
+	TenderService  _interface.TenderService
+	ProposalRepo   _interface.ProposalRepository
+	Storage        storage.BlobStorage
+	Sealer         *sealing.Sealer
+	Authorizer     *Authorizer
+	// MaxSize is the largest accepted file in bytes.
+	MaxSize int64
+}
+
+func NewAttachmentHandler(attachmentRepo _interface.AttachmentRepository, tenderService _interface.TenderService, proposalRepo _interface.ProposalRepository,
+	blobs storage.BlobStorage, sealer *sealing.Sealer, authorizer *Authorizer, maxSize int64) *AttachmentHandler {
+	return &AttachmentHandler{AttachmentRepo: attachmentRepo, TenderService: tenderService, ProposalRepo: proposalRepo, Storage: blobs, Sealer: sealer, Authorizer: authorizer, MaxSize: maxSize}
+}
+
+// UploadTenderAttachment загружает файл тендера.
+// @Summary Загрузка файла тендера
+// @Description Прикрепляет к тендеру файл (спецификацию, чертеж). Принимаются документы, таблицы, изображения, чертежи и архивы (pdf, doc, docx, xls, xlsx, odt, ods, txt, csv, png, jpg, dwg, dxf, zip) не больше ATTACHMENT_MAX_SIZE. Файл с именем уже прикрепленного становится его новой ревизией в текущей версии тендера. Если передана checksum_sha256, она сверяется с содержимым
+// @Tags Attachments
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param file formData file true "Файл"
+// @Param checksum_sha256 formData string false "SHA-256 содержимого в hex"
+// @Success 200 {object} models.Attachment "Загруженный файл"
+// @Failure 400 {string} string "Неверный ID тендера, тип файла или контрольная сумма"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Тендер закрыт"
+// @Failure 413 {string} string "Файл слишком большой"
+// @Failure 500 {string} string "Ошибка при загрузке файла"
+// @Router /api/tenders/{tenderId}/attachments [post]
+func (h *AttachmentHandler) UploadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	tender, responsible := h.authorizeTender(w, r, tenderID)
+	if tender == nil {
+		return
+	}
+
+	if tender.Status == "CLOSED" {
+		http.Error(w, "files of closed tenders cannot be changed", http.StatusConflict)
+		return
+	}
+
+	attachment := models.Attachment{
+		EntityType: models.AttachmentTender,
+		EntityID:   tenderID,
+		TenderID:   tenderID,
+		UploadedBy: &responsible.UserID,
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	if !h.upload(w, r.WithContext(ctx), &attachment) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// GetTenderAttachments возвращает файлы тендера.
+// @Summary Получение файлов тендера
+// @Description Возвращает последние ревизии файлов тендера в текущей или указанной версии тендера. Доступно всем, кто видит тендер
+// @Tags Attachments
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param version query int false "Версия тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.Attachment "Файлы тендера"
+// @Failure 400 {string} string "Неверный ID тендера или версия"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка при получении файлов"
+// @Router /api/tenders/{tenderId}/attachments [get]
+func (h *AttachmentHandler) GetTenderAttachments(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := parseVersion(w, r)
+	if !ok {
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return
+	}
+
+	h.writeAttachments(w, r, models.AttachmentTender, tenderID, version)
+}
+
+// DownloadTenderAttachment отдает содержимое файла тендера.
+// @Summary Скачивание файла тендера
+// @Description Отдает содержимое ревизии файла тендера, в том числе удаленной из текущей версии. ETag содержит SHA-256 содержимого. Доступно всем, кто видит тендер
+// @Tags Attachments
+// @Produce  octet-stream
+// @Param tenderId path string true "ID тендера"
+// @Param attachmentId path string true "ID файла"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {file} file "Содержимое файла"
+// @Failure 400 {string} string "Неверный ID тендера или файла"
+// @Failure 404 {string} string "Тендер или файл не найден"
+// @Failure 500 {string} string "Ошибка при получении файла"
+// @Router /api/tenders/{tenderId}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) DownloadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	tenderID, attachmentID, ok := parseAttachmentIDs(w, r, "tenderId", "tender")
+	if !ok {
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return
+	}
+
+	h.download(w, r, models.AttachmentTender, tenderID, attachmentID)
+}
+
+// DeleteTenderAttachment удаляет файл тендера.
+// @Summary Удаление файла тендера
+// @Description Удаляет последнюю ревизию файла из текущей версии тендера. В прежних версиях файл остается доступен
+// @Tags Attachments
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param attachmentId path string true "ID файла"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.Attachment "Удаленный файл"
+// @Failure 400 {string} string "Неверный ID тендера или файла"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер или последняя ревизия файла не найдены"
+// @Failure 409 {string} string "Тендер закрыт"
+// @Failure 500 {string} string "Ошибка при удалении файла"
+// @Router /api/tenders/{tenderId}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	tenderID, attachmentID, ok := parseAttachmentIDs(w, r, "tenderId", "tender")
+	if !ok {
+		return
+	}
+
+	tender, responsible := h.authorizeTender(w, r, tenderID)
+	if tender == nil {
+		return
+	}
+
+	if tender.Status == "CLOSED" {
+		http.Error(w, "files of closed tenders cannot be changed", http.StatusConflict)
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	h.delete(w, r.WithContext(ctx), models.AttachmentTender, tenderID, attachmentID)
+}
+
+// UploadProposalAttachment загружает файл предложения.
+// @Summary Загрузка файла предложения
+// @Description Прикрепляет к предложению файл (коммерческое предложение, смету) по тем же правилам, что и к тендеру. Файлы можно менять, пока предложение не отменено и по нему не принято решение; файлы запечатанных предложений хранятся зашифрованными и меняются только до срока приема предложений
+// @Tags Attachments
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param file formData file true "Файл"
+// @Param checksum_sha256 formData string false "SHA-256 содержимого в hex"
+// @Success 200 {object} models.Attachment "Загруженный файл"
+// @Failure 400 {string} string "Неверный ID предложения, тип файла или контрольная сумма"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 409 {string} string "Предложение нельзя изменить"
+// @Failure 413 {string} string "Файл слишком большой"
+// @Failure 500 {string} string "Ошибка при загрузке файла"
+// @Router /api/bids/{bidId}/attachments [post]
+func (h *AttachmentHandler) UploadProposalAttachment(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	proposal, tender, responsible := h.authorizeProposalChange(w, r, proposalID)
+	if proposal == nil {
+		return
+	}
+
+	attachment := models.Attachment{
+		EntityType: models.AttachmentProposal,
+		EntityID:   proposalID,
+		TenderID:   proposal.TenderID,
+		UploadedBy: &responsible.UserID,
+		Sealed:     tender.BidsSealed(),
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	if !h.upload(w, r.WithContext(ctx), &attachment) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// GetProposalAttachments возвращает файлы предложения.
+// @Summary Получение файлов предложения
+// @Description Возвращает последние ревизии файлов предложения в текущей или указанной версии предложения. Доступно ответственным организации-участника, а ответственным заказчика — после публикации предложения и вскрытия запечатанных предложений
+// @Tags Attachments
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param version query int false "Версия предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.Attachment "Файлы предложения"
+// @Failure 400 {string} string "Неверный ID предложения или версия"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 500 {string} string "Ошибка при получении файлов"
+// @Router /api/bids/{bidId}/attachments [get]
+func (h *AttachmentHandler) GetProposalAttachments(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := parseVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.authorizeProposalRead(w, r, proposalID) {
+		return
+	}
+
+	h.writeAttachments(w, r, models.AttachmentProposal, proposalID, version)
+}
+
+// DownloadProposalAttachment отдает содержимое файла предложения.
+// @Summary Скачивание файла предложения
+// @Description Отдает содержимое ревизии файла предложения, в том числе удаленной из текущей версии. ETag содержит SHA-256 содержимого. Доступ — как к списку файлов предложения
+// @Tags Attachments
+// @Produce  octet-stream
+// @Param bidId path string true "ID предложения"
+// @Param attachmentId path string true "ID файла"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {file} file "Содержимое файла"
+// @Failure 400 {string} string "Неверный ID предложения или файла"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение или файл не найдены"
+// @Failure 500 {string} string "Ошибка при получении файла"
+// @Router /api/bids/{bidId}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) DownloadProposalAttachment(w http.ResponseWriter, r *http.Request) {
+	proposalID, attachmentID, ok := parseAttachmentIDs(w, r, "bidId", "proposal")
+	if !ok {
+		return
+	}
+
+	if !h.authorizeProposalRead(w, r, proposalID) {
+		return
+	}
+
+	h.download(w, r, models.AttachmentProposal, proposalID, attachmentID)
+}
+
+// DeleteProposalAttachment удаляет файл предложения.
+// @Summary Удаление файла предложения
+// @Description Удаляет последнюю ревизию файла из текущей версии предложения. В прежних версиях файл остается доступен
+// @Tags Attachments
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param attachmentId path string true "ID файла"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.Attachment "Удаленный файл"
+// @Failure 400 {string} string "Неверный ID предложения или файла"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение или последняя ревизия файла не найдены"
+// @Failure 409 {string} string "Предложение нельзя изменить"
+// @Failure 500 {string} string "Ошибка при удалении файла"
+// @Router /api/bids/{bidId}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteProposalAttachment(w http.ResponseWriter, r *http.Request) {
+	proposalID, attachmentID, ok := parseAttachmentIDs(w, r, "bidId", "proposal")
+	if !ok {
+		return
+	}
+
+	proposal, _, responsible := h.authorizeProposalChange(w, r, proposalID)
+	if proposal == nil {
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	h.delete(w, r.WithContext(ctx), models.AttachmentProposal, proposalID, attachmentID)
+}
+
+// upload receives the file of a multipart request, checks its size, type and checksum, stores its
+// contents and records it. On failure it writes the error response and returns false.
+func (h *AttachmentHandler) upload(w http.ResponseWriter, r *http.Request, attachment *models.Attachment) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, h.MaxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "file must be at most "+strconv.FormatInt(h.MaxSize, 10)+" bytes", http.StatusRequestEntityTooLarge)
+			return false
+		}
+
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return false
+	}
+	defer file.Close()
+
+	if header.Size > h.MaxSize {
+		http.Error(w, "file must be at most "+strconv.FormatInt(h.MaxSize, 10)+" bytes", http.StatusRequestEntityTooLarge)
+		return false
+	}
+
+	if header.Size == 0 {
+		http.Error(w, "file is empty", http.StatusBadRequest)
+		return false
+	}
+
+	if header.Filename == "" || len([]rune(header.Filename)) > maxFileNameLength {
+		http.Error(w, "file name is required and must be at most "+strconv.Itoa(maxFileNameLength)+" characters", http.StatusBadRequest)
+		return false
+	}
+
+	sniffed, checksum, err := inspectFile(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	attachmentType, ok := models.AttachmentTypeOf(header.Filename, sniffed)
+	if !ok {
+		http.Error(w, "file type is not accepted or does not match the file contents", http.StatusBadRequest)
+		return false
+	}
+
+	if expected := r.FormValue("checksum_sha256"); expected != "" && !strings.EqualFold(expected, checksum) {
+		http.Error(w, "checksum_sha256 does not match the file contents", http.StatusBadRequest)
+		return false
+	}
+
+	attachment.ID = uuid.New()
+	attachment.FileName = header.Filename
+	attachment.ContentType = attachmentType.ContentType
+	attachment.Size = header.Size
+	attachment.Checksum = checksum
+	attachment.StorageKey = attachment.BlobKey()
+
+	if err = h.store(r, attachment, file); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	err = h.AttachmentRepo.CreateAttachment(r.Context(), attachment)
+	if err != nil {
+		// The blob is not referenced by any attachment, so it is removed right away.
+		if deleteErr := h.Storage.Delete(r.Context(), attachment.StorageKey); deleteErr != nil {
+			log.Printf("Failed to delete blob %s of a failed upload: %v", attachment.StorageKey, deleteErr)
+		}
+	}
+
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, string(attachment.EntityType)+" not found", http.StatusNotFound)
+		return false
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
+
+// store puts the file contents to the blob storage, encrypting the files of sealed bids.
+func (h *AttachmentHandler) store(r *http.Request, attachment *models.Attachment, file multipart.File) error {
+	if !attachment.Sealed {
+		return h.Storage.Put(r.Context(), attachment.StorageKey, file, attachment.Size, attachment.ContentType)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	sealed, err := h.Sealer.SealBlob(data, attachment.TenderID)
+	if err != nil {
+		return err
+	}
+
+	return h.Storage.Put(r.Context(), attachment.StorageKey, bytes.NewReader(sealed), int64(len(sealed)), "application/octet-stream")
+}
+
+func (h *AttachmentHandler) writeAttachments(w http.ResponseWriter, r *http.Request, entityType models.AttachmentEntity, entityID uuid.UUID, version *int) {
+	attachments, err := h.AttachmentRepo.GetAttachments(r.Context(), entityType, entityID, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachments)
+}
+
+func (h *AttachmentHandler) download(w http.ResponseWriter, r *http.Request, entityType models.AttachmentEntity, entityID uuid.UUID, attachmentID uuid.UUID) {
+	attachment, err := h.AttachmentRepo.GetAttachment(r.Context(), entityType, entityID, attachmentID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "attachment not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	blob, err := h.Storage.Get(r.Context(), attachment.StorageKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer blob.Close()
+
+	var body io.Reader = blob
+	if attachment.Sealed {
+		sealed, err := io.ReadAll(blob)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := h.Sealer.OpenBlob(sealed, attachment.TenderID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		body = bytes.NewReader(data)
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("ETag", `"`+attachment.Checksum+`"`)
+	if _, err = io.Copy(w, body); err != nil {
+		log.Printf("Failed to send attachment %s: %v", attachment.ID, err)
+	}
+}
+
+func (h *AttachmentHandler) delete(w http.ResponseWriter, r *http.Request, entityType models.AttachmentEntity, entityID uuid.UUID, attachmentID uuid.UUID) {
+	attachment, err := h.AttachmentRepo.DeleteAttachment(r.Context(), entityType, entityID, attachmentID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "attachment not found or not the latest revision", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// authorizeTender loads the tender and checks that the caller manages its tenders.
+func (h *AttachmentHandler) authorizeTender(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID) (*models.Tender, *models.OrganizationResponsible) {
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil, nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, tender.OrganizationID, username, models.PermissionManageTenders)
+	if responsible == nil {
+		return nil, nil
+	}
+
+	return tender, responsible
+}
+
+// authorizeProposalChange loads the proposal and its tender and checks that the caller manages the bids
+// of the proposal organization and that the proposal can still be changed, as EditProposal does.
+func (h *AttachmentHandler) authorizeProposalChange(w http.ResponseWriter, r *http.Request, proposalID uuid.UUID) (*models.Proposal, *models.Tender, *models.OrganizationResponsible) {
+	proposal, tender := loadProposal(w, r, h.ProposalRepo, h.TenderService, proposalID)
+	if proposal == nil {
+		return nil, nil, nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, proposal.OrganizationID, username, models.PermissionManageBids)
+	if responsible == nil {
+		return nil, nil, nil
+	}
+
+	if proposal.Status != "CREATED" && proposal.Status != "PUBLISHED" {
+		http.Error(w, "files of canceled and decided proposals cannot be changed", http.StatusConflict)
+		return nil, nil, nil
+	}
+
+	if tender.BidsSealed() && !tender.AcceptsBids(time.Now()) {
+		http.Error(w, "submission deadline has passed", http.StatusConflict)
+		return nil, nil, nil
+	}
+
+	return proposal, tender, responsible
+}
+
+// authorizeProposalRead checks that the caller may see the files of the proposal: the responsibles of the
+// proposal organization always, the responsibles of the tender organization once the proposal is published
+// and the bids are not sealed. On failure it writes the error response and returns false.
+func (h *AttachmentHandler) authorizeProposalRead(w http.ResponseWriter, r *http.Request, proposalID uuid.UUID) bool {
+	proposal, tender := loadProposal(w, r, h.ProposalRepo, h.TenderService, proposalID)
+	if proposal == nil {
+		return false
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if username == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return false
+	}
+
+	bidder, err := h.Authorizer.allowed(r, proposal.OrganizationID, username, models.PermissionViewBids)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	if bidder {
+		return true
+	}
+
+	if proposal.Status == "CREATED" || proposal.Status == "CANCELED" || tender.BidsSealed() {
+		http.Error(w, "user is not responsible for the organization", http.StatusForbidden)
+		return false
+	}
+
+	return h.Authorizer.authorize(w, r, tender.OrganizationID, username, models.PermissionViewBids) != nil
+}
+
+// inspectFile detects the type of the file from its first bytes and computes its SHA-256. The file is
+// rewound afterwards.
+func inspectFile(file multipart.File) (string, string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", "", errors.Wrap(err, "failed to read file")
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", "", errors.Wrap(err, "failed to rewind file")
+	}
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", "", errors.Wrap(err, "failed to read file")
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", "", errors.Wrap(err, "failed to rewind file")
+	}
+
+	return http.DetectContentType(head[:n]), hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// parseAttachmentIDs parses the IDs of the tender or proposal and of the attachment from the path.
+func parseAttachmentIDs(w http.ResponseWriter, r *http.Request, entityVar string, entityName string) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	entityID, err := uuid.Parse(vars[entityVar])
+	if err != nil {
+		http.Error(w, "invalid "+entityName+" ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	attachmentID, err := uuid.Parse(vars["attachmentId"])
+	if err != nil {
+		http.Error(w, "invalid attachment ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return entityID, attachmentID, true
+}
+
+// parseVersion parses the optional version query parameter.
+func parseVersion(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	value := r.URL.Query().Get("version")
+	if value == "" {
+		return nil, true
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		http.Error(w, "version must be a positive integer", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &version, true
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuctionHandler struct {
+	AuctionRepo   _interface.AuctionRepository
+	TenderService _interface.TenderService
+	ProposalRepo  _interface.ProposalRepository
+	Authorizer    *Authorizer
+}
+
+func NewAuctionHandler(auctionRepo _interface.AuctionRepository, tenderService _interface.TenderService, proposalRepo _interface.ProposalRepository, authorizer *Authorizer) *AuctionHandler {
+	return &AuctionHandler{AuctionRepo: auctionRepo, TenderService: tenderService, ProposalRepo: proposalRepo, Authorizer: authorizer}
+}
+
+// PlaceBid принимает ставку аукциона на понижение.
+// @Summary Ставка аукциона
+// @Description Снижает цену опубликованного предложения на тендер-аукцион. Первая ставка не должна превышать начальную цену (budgetMax), каждая следующая должна быть ниже лучшей ставки не меньше чем на minDecrement. Ставки принимаются строго по очереди. Ставка за snipingExtension секунд до конца раунда продлевает раунд; аукцион заканчивается после раунда без ставок
+// @Tags Auctions
+// @Accept  json
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param bid body models.AuctionBid true "Ставка: proposal_id, price и необязательная currency"
+// @Success 200 {object} models.AuctionBid "Принятая ставка"
+// @Failure 400 {string} string "Неверный ID тендера или данные ставки"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер или предложение не найдены"
+// @Failure 409 {string} string "Аукцион не идет или цена не ниже лучшей ставки на шаг"
+// @Failure 500 {string} string "Ошибка при приеме ставки"
+// @Router /api/tenders/{tenderId}/auction/bids [post]
+func (h *AuctionHandler) PlaceBid(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	var bid models.AuctionBid
+	if err := json.NewDecoder(r.Body).Decode(&bid); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	proposal, err := h.ProposalRepo.GetProposalByID(r.Context(), bid.ProposalID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "proposal not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if proposal.TenderID != tenderID {
+		http.Error(w, "proposal does not belong to the tender", http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, proposal.OrganizationID, username, models.PermissionManageBids)
+	if responsible == nil {
+		return
+	}
+
+	tender := h.auctionTender(w, r, tenderID, responsible.Username)
+	if tender == nil {
+		return
+	}
+
+	if bid.Currency == "" {
+		bid.Currency = tender.Currency
+	}
+
+	if bid.Currency != tender.Currency {
+		http.Error(w, "price must be in "+string(tender.Currency), http.StatusBadRequest)
+		return
+	}
+
+	if tender.BudgetMin != nil && bid.Price < *tender.BudgetMin {
+		http.Error(w, "price must not be less than "+tender.BudgetMin.String(), http.StatusBadRequest)
+		return
+	}
+
+	bid.TenderID = tenderID
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	err = h.AuctionRepo.PlaceBid(ctx, &bid)
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bid)
+}
+
+// GetLeaderboard возвращает текущее состояние аукциона.
+// @Summary Таблица лидеров аукциона
+// @Description Возвращает текущий раунд, время его окончания, лучшую цену и опубликованные предложения, упорядоченные по их лучшей ставке. Состояние рассчитывается на момент запроса
+// @Tags Auctions
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.Leaderboard "Таблица лидеров"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден или не является аукционом"
+// @Failure 500 {string} string "Ошибка при получении таблицы лидеров"
+// @Router /api/tenders/{tenderId}/auction/leaderboard [get]
+func (h *AuctionHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	tender := h.auctionTender(w, r, tenderID, username)
+	if tender == nil {
+		return
+	}
+
+	leaderboard, err := h.AuctionRepo.GetLeaderboard(r.Context(), tenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	leaderboard.Advance(time.Now(), tender)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(leaderboard)
+}
+
+// auctionTender loads the tender and checks that it is an auction visible to the user. On failure it
+// writes the error response and returns nil.
+func (h *AuctionHandler) auctionTender(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID, username string) *models.Tender {
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return nil
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	if tender.Kind != models.TenderAuction {
+		http.Error(w, "tender is not an auction", http.StatusNotFound)
+		return nil
+	}
+
+	return tender
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuditHandler struct {
+	AuditRepo _interface.AuditRepository
+}
+
+func NewAuditHandler(auditRepo _interface.AuditRepository) *AuditHandler {
+	return &AuditHandler{AuditRepo: auditRepo}
+}
+
+// GetAuditEvents возвращает журнал изменений тендеров и предложений.
+// @Summary Получение журнала аудита
+// @Description Возвращает изменения тендеров и предложений организаций, за которые отвечает пользователь, от новых к старым
+// @Tags Audit
+// @Produce  json
+// @Param entity query string false "ID тендера, предложения или отзыва"
+// @Param actor query string false "Имя пользователя, выполнившего изменение"
+// @Param from query string false "Начало периода в формате RFC3339"
+// @Param to query string false "Конец периода в формате RFC3339"
+// @Param limit query int false "Максимальное число событий (по умолчанию 5, не больше 50)"
+// @Param offset query int false "Сколько событий пропустить"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.AuditEvent "Список событий"
+// @Failure 400 {string} string "Неверные параметры фильтра или пагинации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 500 {string} string "Ошибка при получении журнала аудита"
+// @Router /api/audit [get]
+func (h *AuditHandler) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if username == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.AuditFilter{
+		Username: username,
+		Actor:    r.URL.Query().Get("actor"),
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	if value := r.URL.Query().Get("entity"); value != "" {
+		entityID, err := uuid.Parse(value)
+		if err != nil {
+			http.Error(w, "invalid entity ID", http.StatusBadRequest)
+			return
+		}
+		filter.EntityID = &entityID
+	}
+
+	if filter.From, err = parseTimeParam(r, "from"); err != nil {
+		http.Error(w, "invalid from: expected RFC3339 time", http.StatusBadRequest)
+		return
+	}
+
+	if filter.To, err = parseTimeParam(r, "to"); err != nil {
+		http.Error(w, "invalid to: expected RFC3339 time", http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.AuditRepo.GetAuditEvents(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(events)
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
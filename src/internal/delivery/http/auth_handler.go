@@ -0,0 +1,162 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"avito_2024/src/internal/auth"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuthHandler struct {
+	AuthRepo _interface.AuthRepository
+	Tokens   *auth.TokenManager
+}
+
+func NewAuthHandler(authRepo _interface.AuthRepository, tokens *auth.TokenManager) *AuthHandler {
+	return &AuthHandler{AuthRepo: authRepo, Tokens: tokens}
+}
+
+// Login выдает токен доступа сотруднику.
+// @Summary Получение токена доступа
+// @Description Проверяет имя пользователя и пароль сотрудника и возвращает подписанный JWT
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param credentials body models.LoginRequest true "Имя пользователя и пароль"
+// @Success 200 {object} models.TokenResponse "Токен доступа"
+// @Failure 400 {string} string "Неверный формат запроса"
+// @Failure 401 {string} string "Неверное имя пользователя или пароль"
+// @Failure 500 {string} string "Ошибка сервиса"
+// @Router /api/auth/login [post]
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request models.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if request.Username == "" || request.Password == "" {
+		http.Error(w, "username and password are required", http.StatusBadRequest)
+		return
+	}
+
+	credentials, err := h.AuthRepo.GetCredentials(r.Context(), request.Username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "invalid username or password", http.StatusUnauthorized)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if credentials.PasswordHash == nil ||
+		bcrypt.CompareHashAndPassword([]byte(*credentials.PasswordHash), []byte(request.Password)) != nil {
+		http.Error(w, "invalid username or password", http.StatusUnauthorized)
+		return
+	}
+
+	token, expiresAt, err := h.Tokens.Issue(auth.Identity{UserID: credentials.ID, Username: credentials.Username, TokenVersion: credentials.TokenVersion})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.TokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// SetPassword меняет пароль сотрудника.
+// @Summary Смена пароля
+// @Description Устанавливает новый пароль по текущему паролю или по одноразовому токену сброса, выданному владельцем организации сотрудника. Токен доступа не требуется
+// @Tags Auth
+// @Accept  json
+// @Param request body models.SetPasswordRequest true "Имя пользователя, текущий пароль или токен сброса и новый пароль"
+// @Success 200 {string} string "Пароль успешно изменен"
+// @Failure 400 {string} string "Неверный формат запроса или слишком короткий пароль"
+// @Failure 401 {string} string "Неверное имя пользователя, пароль или токен сброса"
+// @Failure 500 {string} string "Ошибка сервиса"
+// @Router /api/auth/password [put]
+func (h *AuthHandler) SetPassword(w http.ResponseWriter, r *http.Request) {
+	var request models.SetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if request.Username == "" || (request.CurrentPassword == "") == (request.ResetToken == "") {
+		http.Error(w, "username and either current_password or reset_token are required", http.StatusBadRequest)
+		return
+	}
+
+	if len(request.NewPassword) < minPasswordLength {
+		http.Error(w, "password must be at least 8 characters", http.StatusBadRequest)
+		return
+	}
+
+	credentials, err := h.AuthRepo.GetCredentials(r.Context(), request.Username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "invalid username or credentials", http.StatusUnauthorized)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !passwordChangeAllowed(credentials, request) {
+		http.Error(w, "invalid username or credentials", http.StatusUnauthorized)
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = h.AuthRepo.SetPassword(r.Context(), credentials, string(passwordHash))
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "invalid username or credentials", http.StatusUnauthorized)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Пароль успешно изменен"))
+}
+
+func passwordChangeAllowed(credentials *models.EmployeeCredentials, request models.SetPasswordRequest) bool {
+	if request.CurrentPassword != "" {
+		return credentials.PasswordHash != nil &&
+			bcrypt.CompareHashAndPassword([]byte(*credentials.PasswordHash), []byte(request.CurrentPassword)) == nil
+	}
+
+	if credentials.PasswordResetHash == nil || credentials.PasswordResetExpiresAt == nil ||
+		!time.Now().Before(*credentials.PasswordResetExpiresAt) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(*credentials.PasswordResetHash), []byte(hashResetToken(request.ResetToken))) == 1
+}
+
+func hashResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
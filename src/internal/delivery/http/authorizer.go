@@ -0,0 +1,74 @@
+package http
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+// Authorizer checks the caller's role in an organization against the permission matrix.
+type Authorizer struct {
+	Responsibles _interface.ResponsibleRepository
+}
+
+func NewAuthorizer(responsibles _interface.ResponsibleRepository) *Authorizer {
+	return &Authorizer{Responsibles: responsibles}
+}
+
+// authorize returns the caller's responsible record if their role in the organization grants
+// the permission. Otherwise it writes the error response and returns nil.
+func (a *Authorizer) authorize(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, username string, permission models.Permission) *models.OrganizationResponsible {
+	if username == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return nil
+	}
+
+	responsible, err := a.Responsibles.GetResponsible(r.Context(), orgID, username)
+	return a.check(w, responsible, err, permission)
+}
+
+// authorizeUserID is authorize for handlers that identify the caller by employee ID.
+func (a *Authorizer) authorizeUserID(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, userID uuid.UUID, permission models.Permission) *models.OrganizationResponsible {
+	responsible, err := a.Responsibles.GetResponsibleByUserID(r.Context(), orgID, userID)
+	return a.check(w, responsible, err, permission)
+}
+
+func (a *Authorizer) check(w http.ResponseWriter, responsible *models.OrganizationResponsible, err error, permission models.Permission) *models.OrganizationResponsible {
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "user is not responsible for the organization", http.StatusForbidden)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	if !responsible.Role.Can(permission) {
+		http.Error(w, fmt.Sprintf("role %s is not allowed to %s", responsible.Role, permission), http.StatusForbidden)
+		return nil
+	}
+
+	return responsible
+}
+
+// allowed reports whether the caller's role in the organization grants the permission. Unlike authorize,
+// it doesn't write a response, so that access can be granted by one of several organizations.
+func (a *Authorizer) allowed(r *http.Request, orgID uuid.UUID, username string, permission models.Permission) (bool, error) {
+	responsible, err := a.Responsibles.GetResponsible(r.Context(), orgID, username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return responsible.Role.Can(permission), nil
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/auth"
+	"avito_2024/src/internal/domain/interface"
+)
+
+// callerUsername returns the authenticated username. Without a token (compatibility mode)
+// it falls back to the username passed in the query or body.
+func callerUsername(r *http.Request, fallback string) string {
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		return identity.Username
+	}
+
+	return fallback
+}
+
+// callerID returns the authenticated employee ID, falling back like callerUsername.
+func callerID(r *http.Request, fallback uuid.UUID) uuid.UUID {
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		return identity.UserID
+	}
+
+	return fallback
+}
+
+// callerEmployeeID returns the ID of the authenticated employee or, without a token, of the employee
+// named in the username query parameter. Otherwise it writes the error response.
+func callerEmployeeID(w http.ResponseWriter, r *http.Request, employees _interface.EmployeeRepository) (uuid.UUID, bool) {
+	if userID := callerID(r, uuid.Nil); userID != uuid.Nil {
+		return userID, true
+	}
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	employee, err := employees.GetEmployeeByUsername(r.Context(), username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "user not found", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return uuid.Nil, false
+	}
+
+	return employee.ID, true
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"avito_2024/src/internal/auth"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const (
+	maxUsernameLength = 50
+	maxNameLength     = 50
+	minPasswordLength = 8
+)
+
+type EmployeeHandler struct {
+	EmployeeRepo _interface.EmployeeRepository
+	ResetTTL     time.Duration
+}
+
+func NewEmployeeHandler(employeeRepo _interface.EmployeeRepository, resetTTL time.Duration) *EmployeeHandler {
+	return &EmployeeHandler{EmployeeRepo: employeeRepo, ResetTTL: resetTTL}
+}
+
+// RegisterEmployee регистрирует нового сотрудника.
+// @Summary Регистрация сотрудника
+// @Description Создает сотрудника с уникальным именем пользователя и паролем
+// @Tags Employees
+// @Accept  json
+// @Produce  json
+// @Param employee body models.RegisterEmployeeRequest true "Данные сотрудника"
+// @Success 200 {object} models.Employee "Зарегистрированный сотрудник"
+// @Failure 400 {string} string "Неверные данные сотрудника"
+// @Failure 409 {string} string "Имя пользователя уже занято"
+// @Failure 500 {string} string "Ошибка при регистрации сотрудника"
+// @Router /api/employees/new [post]
+func (h *EmployeeHandler) RegisterEmployee(w http.ResponseWriter, r *http.Request) {
+	var request models.RegisterEmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if request.Username == "" || len(request.Username) > maxUsernameLength {
+		http.Error(w, "username is required and must be at most 50 characters", http.StatusBadRequest)
+		return
+	}
+
+	if len(request.FirstName) > maxNameLength || len(request.LastName) > maxNameLength {
+		http.Error(w, "names must be at most 50 characters", http.StatusBadRequest)
+		return
+	}
+
+	if len(request.Password) < minPasswordLength {
+		http.Error(w, "password must be at least 8 characters", http.StatusBadRequest)
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	employee := models.Employee{
+		Username:  request.Username,
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+	}
+
+	err = h.EmployeeRepo.CreateEmployee(r.Context(), &employee, string(passwordHash))
+	if errors.Cause(err) == models.ErrAlreadyExists {
+		http.Error(w, "username is already taken", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(employee)
+}
+
+// GetEmployee возвращает сотрудника по имени пользователя.
+// @Summary Получение сотрудника
+// @Description Возвращает сотрудника по имени пользователя
+// @Tags Employees
+// @Produce  json
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} models.Employee "Сотрудник"
+// @Failure 404 {string} string "Сотрудник не найден"
+// @Failure 500 {string} string "Ошибка при получении сотрудника"
+// @Router /api/employees/{username} [get]
+func (h *EmployeeHandler) GetEmployee(w http.ResponseWriter, r *http.Request) {
+	employee, err := h.EmployeeRepo.GetEmployeeByUsername(r.Context(), mux.Vars(r)["username"])
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(employee)
+}
+
+// EditEmployee редактирует имя и фамилию сотрудника.
+// @Summary Редактирование сотрудника
+// @Description Обновляет переданные имя и фамилию. Сотрудник может редактировать только себя
+// @Tags Employees
+// @Accept  json
+// @Produce  json
+// @Param username path string true "Имя пользователя"
+// @Param employee body models.Employee true "Новые имя и фамилия"
+// @Success 200 {object} models.Employee "Обновленный сотрудник"
+// @Failure 400 {string} string "Неверные данные сотрудника"
+// @Failure 403 {string} string "Недостаточно прав"
+// @Failure 404 {string} string "Сотрудник не найден или деактивирован"
+// @Failure 500 {string} string "Ошибка при редактировании сотрудника"
+// @Router /api/employees/{username}/edit [patch]
+func (h *EmployeeHandler) EditEmployee(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	var employee models.Employee
+	if err := json.NewDecoder(r.Body).Decode(&employee); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(employee.FirstName) > maxNameLength || len(employee.LastName) > maxNameLength {
+		http.Error(w, "names must be at most 50 characters", http.StatusBadRequest)
+		return
+	}
+
+	if callerUsername(r, r.URL.Query().Get("username")) != username {
+		http.Error(w, "employees can edit only themselves", http.StatusForbidden)
+		return
+	}
+
+	employee.Username = username
+	err := h.EmployeeRepo.EditEmployee(r.Context(), &employee)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(employee)
+}
+
+// DeactivateEmployee деактивирует сотрудника.
+// @Summary Деактивация сотрудника
+// @Description Деактивированный сотрудник не может войти и действовать от имени организаций. Доступно самому сотруднику и владельцам его организации
+// @Tags Employees
+// @Param username path string true "Имя пользователя"
+// @Success 200 {string} string "Сотрудник успешно деактивирован"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав"
+// @Failure 404 {string} string "Сотрудник не найден или уже деактивирован"
+// @Failure 500 {string} string "Ошибка при деактивации сотрудника"
+// @Router /api/employees/{username}/deactivate [put]
+func (h *EmployeeHandler) DeactivateEmployee(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	caller := callerUsername(r, r.URL.Query().Get("username"))
+	if caller == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return
+	}
+
+	if caller != username {
+		allowed, err := h.EmployeeRepo.CheckUserIsOwnerOf(r.Context(), caller, username)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if !allowed {
+			http.Error(w, "not enough rights to deactivate the employee", http.StatusForbidden)
+			return
+		}
+	}
+
+	err := h.EmployeeRepo.DeactivateEmployee(r.Context(), username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Сотрудник успешно деактивирован"))
+}
+
+// ResetPassword выдает токен сброса пароля сотрудника.
+// @Summary Сброс пароля сотрудника
+// @Description Выдает одноразовый токен, по которому сотрудник задает новый пароль через PUT /api/auth/password. Токен возвращается только один раз и заменяет выданный ранее. Доступно владельцам организации сотрудника и только с токеном, в том числе в режиме AUTH_MODE=compat; свой пароль сотрудник меняет по текущему паролю
+// @Tags Employees
+// @Produce  json
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} models.PasswordResetResponse "Токен сброса пароля"
+// @Failure 401 {string} string "Запрос без токена"
+// @Failure 403 {string} string "Недостаточно прав"
+// @Failure 404 {string} string "Сотрудник не найден или деактивирован"
+// @Failure 500 {string} string "Ошибка при сбросе пароля"
+// @Router /api/employees/{username}/password_reset [post]
+func (h *EmployeeHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	// Reset tokens are credentials, so the caller must be authenticated even in compat mode.
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication is required", http.StatusUnauthorized)
+		return
+	}
+	caller := identity.Username
+
+	if caller == username {
+		http.Error(w, "employees change their own password with the current one", http.StatusForbidden)
+		return
+	}
+
+	allowed, err := h.EmployeeRepo.CheckUserIsOwnerOf(r.Context(), caller, username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !allowed {
+		http.Error(w, "not enough rights to reset the password", http.StatusForbidden)
+		return
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	expiresAt := time.Now().Add(h.ResetTTL)
+	err = h.EmployeeRepo.SetPasswordReset(r.Context(), username, hashResetToken(token), expiresAt)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.PasswordResetResponse{ResetToken: token, ExpiresAt: expiresAt})
+}
+
+func generateResetToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", errors.Wrap(err, "failed to generate password reset token")
+	}
+
+	return hex.EncodeToString(token), nil
+}
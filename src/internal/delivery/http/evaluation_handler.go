@@ -0,0 +1,362 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const (
+	maxCriteria            = 20
+	maxCriterionNameLength = 100
+	maxCriterionWeight     = 100
+)
+
+type EvaluationHandler struct {
+	EvaluationRepo _interface.EvaluationRepository
+	TenderService  _interface.TenderService
+	ProposalRepo   _interface.ProposalRepository
+	Authorizer     *Authorizer
+}
+
+func NewEvaluationHandler(evaluationRepo _interface.EvaluationRepository, tenderService _interface.TenderService, proposalRepo _interface.ProposalRepository, authorizer *Authorizer) *EvaluationHandler {
+	return &EvaluationHandler{EvaluationRepo: evaluationRepo, TenderService: tenderService, ProposalRepo: proposalRepo, Authorizer: authorizer}
+}
+
+// GetCriteria возвращает критерии оценки предложений тендера.
+// @Summary Получение критериев оценки
+// @Description Возвращает взвешенные критерии, по которым оцениваются предложения на тендер
+// @Tags Evaluation
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.EvaluationCriterion "Критерии оценки"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка при получении критериев"
+// @Router /api/tenders/{tenderId}/criteria [get]
+func (h *EvaluationHandler) GetCriteria(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return
+	}
+
+	criteria, err := h.EvaluationRepo.GetCriteria(r.Context(), tenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(criteria)
+}
+
+// SetCriteria задает критерии оценки предложений тендера.
+// @Summary Задание критериев оценки
+// @Description Заменяет критерии оценки тендера. Вес критерия — целое число от 1 до 100, итоговая оценка нормируется на сумму весов. Критерии нельзя менять после того, как предложения начали оценивать
+// @Tags Evaluation
+// @Accept  json
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param criteria body []models.EvaluationCriterion true "Критерии: название, тип (price, delivery_time, experience, custom) и вес"
+// @Success 200 {array} models.EvaluationCriterion "Критерии оценки"
+// @Failure 400 {string} string "Неверный ID тендера или критерии"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Тендер закрыт или предложения уже оценивались"
+// @Failure 500 {string} string "Ошибка при сохранении критериев"
+// @Router /api/tenders/{tenderId}/criteria [put]
+func (h *EvaluationHandler) SetCriteria(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	var criteria []models.EvaluationCriterion
+	if err := json.NewDecoder(r.Body).Decode(&criteria); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateCriteria(criteria); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tender := h.authorizeTender(w, r, tenderID, models.PermissionManageTenders)
+	if tender == nil {
+		return
+	}
+
+	if tender.Status == "CLOSED" {
+		http.Error(w, "criteria of closed tenders cannot be changed", http.StatusConflict)
+		return
+	}
+
+	err = h.EvaluationRepo.SetCriteria(r.Context(), tenderID, criteria)
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, "criteria cannot be changed after bids have been scored", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(criteria)
+}
+
+// GetRanking возвращает рейтинг предложений тендера.
+// @Summary Рейтинг предложений
+// @Description Возвращает опубликованные предложения, упорядоченные по взвешенной оценке: для каждого критерия берется средняя оценка ответственных, неоцененные критерии считаются нулем. При равной оценке выше предложение с меньшей ценой
+// @Tags Evaluation
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.RankingEntry "Рейтинг предложений"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка при расчете рейтинга"
+// @Router /api/tenders/{tenderId}/ranking [get]
+func (h *EvaluationHandler) GetRanking(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeTender(w, r, tenderID, models.PermissionViewBids) == nil {
+		return
+	}
+
+	ranking, err := h.EvaluationRepo.GetRanking(r.Context(), tenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ranking)
+}
+
+// ScoreProposal сохраняет оценки предложения по критериям.
+// @Summary Оценка предложения
+// @Description Ответственный за организацию тендера оценивает опубликованное предложение по критериям тендера от 0 до 10. Повторная оценка по критерию заменяет прежнюю
+// @Tags Evaluation
+// @Accept  json
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param scores body []models.ProposalScore true "Оценки: ID критерия и оценка"
+// @Success 200 {array} models.ProposalScore "Сохраненные оценки"
+// @Failure 400 {string} string "Неверный ID предложения или оценки"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 409 {string} string "Предложение не опубликовано или еще не вскрыто"
+// @Failure 500 {string} string "Ошибка при сохранении оценок"
+// @Router /api/bids/{bidId}/scores [put]
+func (h *EvaluationHandler) ScoreProposal(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	var scores []models.ProposalScore
+	if err := json.NewDecoder(r.Body).Decode(&scores); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(scores) == 0 {
+		http.Error(w, "at least one score is required", http.StatusBadRequest)
+		return
+	}
+
+	proposal, tender, responsible := h.authorizeProposal(w, r, proposalID, models.PermissionScoreBids)
+	if responsible == nil {
+		return
+	}
+
+	if proposal.Status != "PUBLISHED" {
+		http.Error(w, "only published proposals can be scored", http.StatusConflict)
+		return
+	}
+
+	if tender.BidsSealed() {
+		http.Error(w, "bids of the tender are not opened yet", http.StatusConflict)
+		return
+	}
+
+	criteria, err := h.EvaluationRepo.GetCriteria(r.Context(), proposal.TenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := validateScores(scores, criteria); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.EvaluationRepo.SetScores(r.Context(), proposalID, responsible.UserID, scores); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scores)
+}
+
+// GetProposalScores возвращает оценки предложения.
+// @Summary Получение оценок предложения
+// @Description Возвращает оценки предложения всеми ответственными по всем критериям
+// @Tags Evaluation
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.ProposalScore "Оценки предложения"
+// @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 500 {string} string "Ошибка при получении оценок"
+// @Router /api/bids/{bidId}/scores [get]
+func (h *EvaluationHandler) GetProposalScores(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, _, responsible := h.authorizeProposal(w, r, proposalID, models.PermissionViewBids); responsible == nil {
+		return
+	}
+
+	scores, err := h.EvaluationRepo.GetScores(r.Context(), proposalID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scores)
+}
+
+// authorizeTender loads the tender and checks the caller's permission in its organization.
+func (h *EvaluationHandler) authorizeTender(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID, permission models.Permission) *models.Tender {
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if h.Authorizer.authorize(w, r, tender.OrganizationID, username, permission) == nil {
+		return nil
+	}
+
+	return tender
+}
+
+// authorizeProposal loads the proposal and its tender and checks the caller's permission in the organization of the tender.
+func (h *EvaluationHandler) authorizeProposal(w http.ResponseWriter, r *http.Request, proposalID uuid.UUID, permission models.Permission) (*models.Proposal, *models.Tender, *models.OrganizationResponsible) {
+	proposal, err := h.ProposalRepo.GetProposalByID(r.Context(), proposalID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "proposal not found", http.StatusNotFound)
+		return nil, nil, nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, nil
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), proposal.TenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	return proposal, tender, h.Authorizer.authorize(w, r, tender.OrganizationID, username, permission)
+}
+
+func validateCriteria(criteria []models.EvaluationCriterion) error {
+	if len(criteria) > maxCriteria {
+		return errors.Errorf("at most %d criteria are allowed", maxCriteria)
+	}
+
+	names := make(map[string]bool, len(criteria))
+	for _, criterion := range criteria {
+		if criterion.Name == "" || len([]rune(criterion.Name)) > maxCriterionNameLength {
+			return errors.Errorf("criterion name is required and must be at most %d characters", maxCriterionNameLength)
+		}
+
+		if names[criterion.Name] {
+			return errors.Errorf("duplicate criterion %s", criterion.Name)
+		}
+		names[criterion.Name] = true
+
+		if !criterion.Kind.IsValid() {
+			return errors.New("criterion kind must be one of price, delivery_time, experience, custom")
+		}
+
+		if criterion.Weight < 1 || criterion.Weight > maxCriterionWeight {
+			return errors.Errorf("criterion weight must be from 1 to %d", maxCriterionWeight)
+		}
+	}
+
+	return nil
+}
+
+func validateScores(scores []models.ProposalScore, criteria []models.EvaluationCriterion) error {
+	known := make(map[uuid.UUID]bool, len(criteria))
+	for _, criterion := range criteria {
+		known[criterion.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(scores))
+	for _, score := range scores {
+		if !known[score.CriterionID] {
+			return errors.Errorf("criterion %s does not belong to the tender", score.CriterionID)
+		}
+
+		if seen[score.CriterionID] {
+			return errors.Errorf("duplicate score by criterion %s", score.CriterionID)
+		}
+		seen[score.CriterionID] = true
+
+		if score.Score < models.MinScore || score.Score > models.MaxScore {
+			return errors.Errorf("score must be from %d to %d", models.MinScore, models.MaxScore)
+		}
+	}
+
+	return nil
+}
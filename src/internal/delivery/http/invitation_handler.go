@@ -0,0 +1,202 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type InvitationHandler struct {
+	InvitationRepo _interface.InvitationRepository
+	TenderService  _interface.TenderService
+	Authorizer     *Authorizer
+}
+
+func NewInvitationHandler(invitationRepo _interface.InvitationRepository, tenderService _interface.TenderService, authorizer *Authorizer) *InvitationHandler {
+	return &InvitationHandler{InvitationRepo: invitationRepo, TenderService: tenderService, Authorizer: authorizer}
+}
+
+// CreateInvitation приглашает организацию или сотрудника в приватный тендер.
+// @Summary Приглашение в приватный тендер
+// @Description Приглашает в приватный тендер организацию (organization_id) или сотрудника (username). Приглашенный сотрудник и ответственные приглашенной организации видят тендер в списке и могут подавать на него предложения
+// @Tags Invitations
+// @Accept  json
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param invitation body models.InviteRequest true "Приглашаемая организация или сотрудник"
+// @Success 200 {object} models.TenderInvitation "Приглашение"
+// @Failure 400 {string} string "Неверный ID тендера или данные"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер, организация или сотрудник не найдены"
+// @Failure 409 {string} string "Тендер не приватный или приглашение уже есть"
+// @Failure 500 {string} string "Ошибка при создании приглашения"
+// @Router /api/tenders/{tenderId}/invitations [post]
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.InviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if (request.OrganizationID == nil) == (request.Username == "") {
+		http.Error(w, "exactly one of organization_id and username is required", http.StatusBadRequest)
+		return
+	}
+
+	responsible := h.privateTender(w, r, tenderID)
+	if responsible == nil {
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	invitation, err := h.InvitationRepo.CreateInvitation(ctx, tenderID, request, responsible.Username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "organization or employee not found", http.StatusNotFound)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrAlreadyExists {
+		http.Error(w, "already invited to the tender", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invitation)
+}
+
+// GetInvitations возвращает приглашения в приватный тендер.
+// @Summary Получение приглашений
+// @Description Возвращает приглашения в приватный тендер, включая отозванные
+// @Tags Invitations
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.TenderInvitation "Приглашения"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Тендер не приватный"
+// @Failure 500 {string} string "Ошибка при получении приглашений"
+// @Router /api/tenders/{tenderId}/invitations [get]
+func (h *InvitationHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.privateTender(w, r, tenderID) == nil {
+		return
+	}
+
+	invitations, err := h.InvitationRepo.GetInvitations(r.Context(), tenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invitations)
+}
+
+// RevokeInvitation отзывает приглашение в приватный тендер.
+// @Summary Отзыв приглашения
+// @Description Отзывает приглашение: тендер пропадает из списка приглашенного, и он больше не может подавать предложения. Уже поданные предложения сохраняются
+// @Tags Invitations
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param invitationId path string true "ID приглашения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.TenderInvitation "Отозванное приглашение"
+// @Failure 400 {string} string "Неверный ID тендера или приглашения"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер или действующее приглашение не найдены"
+// @Failure 409 {string} string "Тендер не приватный"
+// @Failure 500 {string} string "Ошибка при отзыве приглашения"
+// @Router /api/tenders/{tenderId}/invitations/{invitationId} [delete]
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenderID, err := uuid.Parse(vars["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	invitationID, err := uuid.Parse(vars["invitationId"])
+	if err != nil {
+		http.Error(w, "invalid invitation ID", http.StatusBadRequest)
+		return
+	}
+
+	responsible := h.privateTender(w, r, tenderID)
+	if responsible == nil {
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	invitation, err := h.InvitationRepo.RevokeInvitation(ctx, tenderID, invitationID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "invitation not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invitation)
+}
+
+// privateTender loads the tender, checks that it is private and that the caller manages its tenders.
+// On failure it writes the error response and returns nil.
+func (h *InvitationHandler) privateTender(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID) *models.OrganizationResponsible {
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, tender.OrganizationID, username, models.PermissionManageTenders)
+	if responsible == nil {
+		return nil
+	}
+
+	if tender.Visibility != models.VisibilityPrivate {
+		http.Error(w, "invitations are only used by private tenders", http.StatusConflict)
+		return nil
+	}
+
+	return responsible
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const (
+	maxLots                 = 50
+	maxLotTitleLength       = 100
+	maxLotServiceTypeLength = 50
+)
+
+type LotHandler struct {
+	LotRepo       _interface.LotRepository
+	TenderService _interface.TenderService
+	Authorizer    *Authorizer
+}
+
+func NewLotHandler(lotRepo _interface.LotRepository, tenderService _interface.TenderService, authorizer *Authorizer) *LotHandler {
+	return &LotHandler{LotRepo: lotRepo, TenderService: tenderService, Authorizer: authorizer}
+}
+
+// GetLots возвращает лоты тендера.
+// @Summary Получение лотов
+// @Description Возвращает лоты тендера с их статусом и присужденным предложением
+// @Tags Lots
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.TenderLot "Лоты тендера"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка при получении лотов"
+// @Router /api/tenders/{tenderId}/lots [get]
+func (h *LotHandler) GetLots(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return
+	}
+
+	lots, err := h.LotRepo.GetLots(r.Context(), tenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lots)
+}
+
+// SetLots задает лоты тендера.
+// @Summary Задание лотов
+// @Description Заменяет лоты тендера. У каждого лота свой тип услуг и бюджет в валюте тендера. Предложения на тендер с лотами подаются на один или несколько лотов, одобренному предложению присуждаются его лоты, а тендер закрывается, когда присуждены все лоты. Лоты нельзя менять после подачи первого предложения
+// @Tags Lots
+// @Accept  json
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param lots body []models.TenderLot true "Лоты: название, описание, тип услуг и бюджет"
+// @Success 200 {array} models.TenderLot "Лоты тендера"
+// @Failure 400 {string} string "Неверный ID тендера или лоты"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Тендер закрыт, является аукционом или на него уже поданы предложения"
+// @Failure 500 {string} string "Ошибка при сохранении лотов"
+// @Router /api/tenders/{tenderId}/lots [put]
+func (h *LotHandler) SetLots(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	var lots []models.TenderLot
+	if err := json.NewDecoder(r.Body).Decode(&lots); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tender, responsible := h.authorizeTender(w, r, tenderID, models.PermissionManageTenders)
+	if tender == nil {
+		return
+	}
+
+	if err := validateLots(lots, tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if tender.Status == "CLOSED" {
+		http.Error(w, "lots of closed tenders cannot be changed", http.StatusConflict)
+		return
+	}
+
+	if tender.Kind == models.TenderAuction {
+		http.Error(w, "auction tenders cannot have lots", http.StatusConflict)
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	err = h.LotRepo.SetLots(ctx, tenderID, lots)
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, "lots cannot be changed after bids have been submitted", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lots)
+}
+
+// authorizeTender loads the tender and checks the caller's permission in its organization.
+func (h *LotHandler) authorizeTender(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID, permission models.Permission) (*models.Tender, *models.OrganizationResponsible) {
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil, nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, tender.OrganizationID, username, permission)
+	if responsible == nil {
+		return nil, nil
+	}
+
+	return tender, responsible
+}
+
+func validateLots(lots []models.TenderLot, tender *models.Tender) error {
+	if len(lots) > maxLots {
+		return errors.Errorf("at most %d lots are allowed", maxLots)
+	}
+
+	titles := make(map[string]bool, len(lots))
+	for _, lot := range lots {
+		if lot.Title == "" || len([]rune(lot.Title)) > maxLotTitleLength {
+			return errors.Errorf("lot title is required and must be at most %d characters", maxLotTitleLength)
+		}
+
+		if titles[lot.Title] {
+			return errors.Errorf("duplicate lot %s", lot.Title)
+		}
+		titles[lot.Title] = true
+
+		if lot.ServiceType == "" || len([]rune(lot.ServiceType)) > maxLotServiceTypeLength {
+			return errors.Errorf("lot service type is required and must be at most %d characters", maxLotServiceTypeLength)
+		}
+
+		if (lot.BudgetMin != nil || lot.BudgetMax != nil) && tender.Currency == "" {
+			return errors.New("lot budgets require the tender currency")
+		}
+
+		if lot.BudgetMin != nil && lot.BudgetMax != nil && *lot.BudgetMax < *lot.BudgetMin {
+			return errors.Errorf("budget_max of lot %s must not be less than budget_min", lot.Title)
+		}
+	}
+
+	return nil
+}
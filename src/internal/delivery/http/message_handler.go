@@ -0,0 +1,230 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const maxMessageLength = 4000
+
+type MessageHandler struct {
+	MessageRepo   _interface.MessageRepository
+	ProposalRepo  _interface.ProposalRepository
+	TenderService _interface.TenderService
+	Authorizer    *Authorizer
+}
+
+func NewMessageHandler(messageRepo _interface.MessageRepository, proposalRepo _interface.ProposalRepository, tenderService _interface.TenderService, authorizer *Authorizer) *MessageHandler {
+	return &MessageHandler{MessageRepo: messageRepo, ProposalRepo: proposalRepo, TenderService: tenderService, Authorizer: authorizer}
+}
+
+// SendMessage отправляет сообщение в переписку по предложению.
+// @Summary Отправка сообщения по предложению
+// @Description Отправляет сообщение в переписку между заказчиком и организацией-участником. Писать могут ответственные с правом переписки обеих сторон по опубликованному, не отмененному и не запечатанному предложению. Другая сторона получает уведомление bid.message_sent
+// @Tags Messages
+// @Accept  json
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param message body models.SendMessageRequest true "Текст сообщения"
+// @Success 200 {object} models.ProposalMessage "Сообщение"
+// @Failure 400 {string} string "Неверный ID предложения или текст"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Пользователь не участвует в переписке"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 409 {string} string "Переписка по предложению недоступна"
+// @Failure 500 {string} string "Ошибка при отправке сообщения"
+// @Router /api/bids/{bidId}/messages [post]
+func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.SendMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request.Body = strings.TrimSpace(request.Body)
+	if request.Body == "" || len([]rune(request.Body)) > maxMessageLength {
+		http.Error(w, "body is required and must be at most 4000 characters", http.StatusBadRequest)
+		return
+	}
+
+	proposal, tender, side, responsible := h.participant(w, r, proposalID)
+	if responsible == nil {
+		return
+	}
+
+	if proposal.Status == "CREATED" || proposal.Status == "CANCELED" {
+		http.Error(w, "messages are only sent on published proposals", http.StatusConflict)
+		return
+	}
+
+	if tender.BidsSealed() {
+		http.Error(w, "bids of the tender are sealed", http.StatusConflict)
+		return
+	}
+
+	message := models.ProposalMessage{
+		ProposalID:     proposalID,
+		OrganizationID: responsible.OrganizationID,
+		SenderSide:     side,
+		AuthorID:       &responsible.UserID,
+		Body:           request.Body,
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	if err := h.MessageRepo.CreateMessage(ctx, &message); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message)
+}
+
+// GetMessages возвращает переписку по предложению.
+// @Summary Переписка по предложению
+// @Description Возвращает сообщения переписки между заказчиком и организацией-участником, от новых к старым. Переписку видят только ответственные с правом переписки обеих сторон
+// @Tags Messages
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param limit query int false "Максимальное число сообщений (по умолчанию 5, не больше 50)"
+// @Param offset query int false "Сколько сообщений пропустить"
+// @Success 200 {array} models.ProposalMessage "Сообщения"
+// @Failure 400 {string} string "Неверный ID предложения или пагинация"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Пользователь не участвует в переписке"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 500 {string} string "Ошибка при получении сообщений"
+// @Router /api/bids/{bidId}/messages [get]
+func (h *MessageHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, _, _, responsible := h.participant(w, r, proposalID); responsible == nil {
+		return
+	}
+
+	messages, err := h.MessageRepo.GetMessages(r.Context(), proposalID, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(messages)
+}
+
+// MarkMessageRead отмечает сообщение прочитанным.
+// @Summary Прочтение сообщения
+// @Description Отмечает прочитанными сообщение другой стороны и все более ранние непрочитанные сообщения этой стороны. Отправитель видит, когда и кем сообщение прочитано
+// @Tags Messages
+// @Produce  json
+// @Param bidId path string true "ID предложения"
+// @Param messageId path string true "ID сообщения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.ProposalMessage "Прочитанное сообщение"
+// @Failure 400 {string} string "Неверный ID предложения или сообщения"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Пользователь не участвует в переписке"
+// @Failure 404 {string} string "Предложение или сообщение не найдены"
+// @Failure 409 {string} string "Сообщение отправлено своей стороной"
+// @Failure 500 {string} string "Ошибка при обновлении сообщения"
+// @Router /api/bids/{bidId}/messages/{messageId}/read [put]
+func (h *MessageHandler) MarkMessageRead(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	proposalID, err := uuid.Parse(vars["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["messageId"])
+	if err != nil {
+		http.Error(w, "invalid message ID", http.StatusBadRequest)
+		return
+	}
+
+	_, _, side, responsible := h.participant(w, r, proposalID)
+	if responsible == nil {
+		return
+	}
+
+	message, err := h.MessageRepo.MarkRead(r.Context(), proposalID, messageID, side, responsible.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "message not found", http.StatusNotFound)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message)
+}
+
+// participant loads the proposal and its tender and finds the side of the thread the caller is on: the
+// proposal organization, or the tender organization once the proposal is no longer a draft. On failure it
+// writes the error response and returns a nil responsible.
+func (h *MessageHandler) participant(w http.ResponseWriter, r *http.Request, proposalID uuid.UUID) (*models.Proposal, *models.Tender, models.MessageSide, *models.OrganizationResponsible) {
+	proposal, tender := loadProposal(w, r, h.ProposalRepo, h.TenderService, proposalID)
+	if proposal == nil {
+		return nil, nil, "", nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if username == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return nil, nil, "", nil
+	}
+
+	bidder, err := h.Authorizer.allowed(r, proposal.OrganizationID, username, models.PermissionMessageBids)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil, "", nil
+	}
+
+	if bidder {
+		return proposal, tender, models.SideBidder, h.Authorizer.authorize(w, r, proposal.OrganizationID, username, models.PermissionMessageBids)
+	}
+
+	if proposal.Status == "CREATED" {
+		http.Error(w, "user is not responsible for the organization", http.StatusForbidden)
+		return nil, nil, "", nil
+	}
+
+	return proposal, tender, models.SideTender, h.Authorizer.authorize(w, r, tender.OrganizationID, username, models.PermissionMessageBids)
+}
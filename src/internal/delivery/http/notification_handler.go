@@ -0,0 +1,198 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type NotificationHandler struct {
+	NotificationRepo _interface.NotificationRepository
+	EmployeeRepo     _interface.EmployeeRepository
+}
+
+func NewNotificationHandler(notificationRepo _interface.NotificationRepository, employeeRepo _interface.EmployeeRepository) *NotificationHandler {
+	return &NotificationHandler{NotificationRepo: notificationRepo, EmployeeRepo: employeeRepo}
+}
+
+// GetNotifications возвращает уведомления пользователя.
+// @Summary Получение уведомлений
+// @Description Возвращает непрочитанные уведомления пользователя о публикации предложений на тендеры его организации, решениях и отзывах по его предложениям и закрытии тендеров, от новых к старым
+// @Tags Notifications
+// @Produce  json
+// @Param includeRead query bool false "Вернуть также прочитанные уведомления"
+// @Param limit query int false "Максимальное число уведомлений (по умолчанию 5, не больше 50)"
+// @Param offset query int false "Сколько уведомлений пропустить"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.Notification "Список уведомлений"
+// @Failure 400 {string} string "Неверные параметры пагинации"
+// @Failure 401 {string} string "Пользователь не указан или не найден"
+// @Failure 500 {string} string "Ошибка при получении уведомлений"
+// @Router /api/notifications [get]
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	employeeID, ok := callerEmployeeID(w, r, h.EmployeeRepo)
+	if !ok {
+		return
+	}
+
+	includeRead := r.URL.Query().Get("includeRead") == "true"
+	notifications, err := h.NotificationRepo.GetNotifications(r.Context(), employeeID, includeRead, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(notifications)
+}
+
+// MarkNotificationRead отмечает уведомление прочитанным.
+// @Summary Прочтение уведомления
+// @Description Отмечает уведомление пользователя прочитанным
+// @Tags Notifications
+// @Produce  json
+// @Param notificationId path string true "ID уведомления"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.Notification "Прочитанное уведомление"
+// @Failure 400 {string} string "Неверный ID уведомления"
+// @Failure 401 {string} string "Пользователь не указан или не найден"
+// @Failure 404 {string} string "Уведомление не найдено"
+// @Failure 500 {string} string "Ошибка при обновлении уведомления"
+// @Router /api/notifications/{notificationId}/read [put]
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	notificationID, err := uuid.Parse(mux.Vars(r)["notificationId"])
+	if err != nil {
+		http.Error(w, "invalid notification ID", http.StatusBadRequest)
+		return
+	}
+
+	employeeID, ok := callerEmployeeID(w, r, h.EmployeeRepo)
+	if !ok {
+		return
+	}
+
+	notification, err := h.NotificationRepo.MarkRead(r.Context(), employeeID, notificationID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "notification not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(notification)
+}
+
+// MarkAllNotificationsRead отмечает все уведомления прочитанными.
+// @Summary Прочтение всех уведомлений
+// @Description Отмечает все непрочитанные уведомления пользователя прочитанными
+// @Tags Notifications
+// @Produce  json
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {string} string "Уведомления отмечены прочитанными"
+// @Failure 401 {string} string "Пользователь не указан или не найден"
+// @Failure 500 {string} string "Ошибка при обновлении уведомлений"
+// @Router /api/notifications/read_all [put]
+func (h *NotificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	employeeID, ok := callerEmployeeID(w, r, h.EmployeeRepo)
+	if !ok {
+		return
+	}
+
+	if _, err := h.NotificationRepo.MarkAllRead(r.Context(), employeeID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Уведомления отмечены прочитанными"))
+}
+
+// GetNotificationPreferences возвращает настройки уведомлений пользователя.
+// @Summary Получение настроек уведомлений
+// @Description Возвращает для каждого типа событий, включены ли уведомления о нем. По умолчанию все уведомления включены
+// @Tags Notifications
+// @Produce  json
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.NotificationPreference "Настройки уведомлений"
+// @Failure 401 {string} string "Пользователь не указан или не найден"
+// @Failure 500 {string} string "Ошибка при получении настроек"
+// @Router /api/notifications/preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	employeeID, ok := callerEmployeeID(w, r, h.EmployeeRepo)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.NotificationRepo.GetPreferences(r.Context(), employeeID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preferences)
+}
+
+// SetNotificationPreferences изменяет настройки уведомлений пользователя.
+// @Summary Изменение настроек уведомлений
+// @Description Включает или выключает уведомления о переданных типах событий; остальные настройки не меняются
+// @Tags Notifications
+// @Accept  json
+// @Produce  json
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param preferences body []models.NotificationPreference true "Настройки уведомлений"
+// @Success 200 {array} models.NotificationPreference "Настройки уведомлений"
+// @Failure 400 {string} string "Неверные настройки"
+// @Failure 401 {string} string "Пользователь не указан или не найден"
+// @Failure 500 {string} string "Ошибка при сохранении настроек"
+// @Router /api/notifications/preferences [put]
+func (h *NotificationHandler) SetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var preferences []models.NotificationPreference
+	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, preference := range preferences {
+		if !preference.EventType.IsNotifiable() {
+			http.Error(w, "no notifications for event type "+string(preference.EventType), http.StatusBadRequest)
+			return
+		}
+	}
+
+	employeeID, ok := callerEmployeeID(w, r, h.EmployeeRepo)
+	if !ok {
+		return
+	}
+
+	if err := h.NotificationRepo.SetPreferences(r.Context(), employeeID, preferences); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	current, err := h.NotificationRepo.GetPreferences(r.Context(), employeeID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(current)
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type OrganizationHandler struct {
+	OrganizationRepo _interface.OrganizationRepository
+	Authorizer       *Authorizer
+}
+
+func NewOrganizationHandler(organizationRepo _interface.OrganizationRepository, authorizer *Authorizer) *OrganizationHandler {
+	return &OrganizationHandler{OrganizationRepo: organizationRepo, Authorizer: authorizer}
+}
+
+// CreateOrganization создает новую организацию.
+// @Summary Создание организации
+// @Description Создает организацию и назначает создателя ответственным за нее
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param organization body models.Organization true "Данные организации"
+// @Success 200 {object} models.Organization "Созданная организация"
+// @Failure 400 {string} string "Неверные данные организации"
+// @Failure 401 {string} string "Пользователь не найден"
+// @Failure 409 {string} string "Пользователь уже является ответственным в другой организации"
+// @Failure 500 {string} string "Ошибка при создании организации"
+// @Router /api/organizations/new [post]
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var organization models.Organization
+	if err := json.NewDecoder(r.Body).Decode(&organization); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if organization.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	if !organization.Type.IsValid() {
+		http.Error(w, "type must be one of IE, LLC, JSC", http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if username == "" {
+		http.Error(w, "username is required", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.OrganizationRepo.CreateOrganization(r.Context(), &organization, username)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "user not found", http.StatusUnauthorized)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrAlreadyExists {
+		http.Error(w, "user is already responsible for another organization", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(organization)
+}
+
+// GetOrganizations возвращает список организаций.
+// @Summary Получение списка организаций
+// @Description Возвращает организации, отсортированные по названию, с пагинацией
+// @Tags Organizations
+// @Produce  json
+// @Param limit query int false "Максимальное число организаций (по умолчанию 5, не больше 50)"
+// @Param offset query int false "Сколько организаций пропустить"
+// @Success 200 {array} models.Organization "Список организаций"
+// @Failure 400 {string} string "Неверные параметры пагинации"
+// @Failure 500 {string} string "Ошибка при получении организаций"
+// @Router /api/organizations [get]
+func (h *OrganizationHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	organizations, err := h.OrganizationRepo.GetOrganizations(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(organizations)
+}
+
+// GetOrganization возвращает организацию по ее ID.
+// @Summary Получение организации
+// @Description Возвращает организацию по ID
+// @Tags Organizations
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Success 200 {object} models.Organization "Организация"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 404 {string} string "Организация не найдена"
+// @Failure 500 {string} string "Ошибка при получении организации"
+// @Router /api/organizations/{organizationId} [get]
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	organization, err := h.OrganizationRepo.GetOrganizationByID(r.Context(), orgID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "organization not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(organization)
+}
+
+// EditOrganization редактирует организацию.
+// @Summary Редактирование организации
+// @Description Обновляет переданные поля организации. Доступно только владельцам организации
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param organization body models.Organization true "Новые данные организации"
+// @Success 200 {object} models.Organization "Обновленная организация"
+// @Failure 400 {string} string "Неверный ID или данные организации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Организация не найдена"
+// @Failure 500 {string} string "Ошибка при редактировании организации"
+// @Router /api/organizations/{organizationId}/edit [patch]
+func (h *OrganizationHandler) EditOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	var organization models.Organization
+	if err := json.NewDecoder(r.Body).Decode(&organization); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if organization.Type != "" && !organization.Type.IsValid() {
+		http.Error(w, "type must be one of IE, LLC, JSC", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeOrganization(w, r, orgID, models.PermissionManageOrganization) == nil {
+		return
+	}
+
+	organization.ID = orgID
+	err = h.OrganizationRepo.EditOrganization(r.Context(), &organization)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "organization not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(organization)
+}
+
+// DeleteOrganization удаляет организацию.
+// @Summary Удаление организации
+// @Description Помечает организацию удаленной. Доступно только владельцам организации
+// @Tags Organizations
+// @Param organizationId path string true "ID организации"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {string} string "Организация успешно удалена"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Организация не найдена"
+// @Failure 500 {string} string "Ошибка при удалении организации"
+// @Router /api/organizations/{organizationId} [delete]
+func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeOrganization(w, r, orgID, models.PermissionManageOrganization) == nil {
+		return
+	}
+
+	err = h.OrganizationRepo.DeleteOrganization(r.Context(), orgID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "organization not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Организация успешно удалена"))
+}
+
+// authorizeOrganization checks the caller's permission in the organization.
+func (h *OrganizationHandler) authorizeOrganization(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, permission models.Permission) *models.OrganizationResponsible {
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	return h.Authorizer.authorize(w, r, orgID, username, permission)
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLimit = 5
+	maxLimit     = 50
+)
+
+// parsePagination reads limit and offset query parameters with the defaults from the API specification.
+func parsePagination(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 || parsed > maxLimit {
+			return 0, 0, errors.New("invalid limit")
+		}
+		limit = parsed
+	}
+
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
@@ -1,33 +1,49 @@
 package http
 
 import (
+	"avito_2024/src/internal/audit"
 	"avito_2024/src/internal/domain/interface"
 	"avito_2024/src/internal/domain/models"
+	"avito_2024/src/internal/sealing"
+	"database/sql"
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
+const maxFeedbackLength = 1000
+
 type ProposalHandler struct {
-	ProposalRepo _interface.ProposalRepository
+	ProposalRepo  _interface.ProposalRepository
+	TenderService _interface.TenderService
+	LotRepo       _interface.LotRepository
+	Sealer        *sealing.Sealer
+	Authorizer    *Authorizer
 }
 
-func NewProposalHandler(proposalService _interface.ProposalRepository) *ProposalHandler {
-	return &ProposalHandler{ProposalRepo: proposalService}
+func NewProposalHandler(proposalService _interface.ProposalRepository, tenderService _interface.TenderService, lotRepo _interface.LotRepository, sealer *sealing.Sealer, authorizer *Authorizer) *ProposalHandler {
+	return &ProposalHandler{ProposalRepo: proposalService, TenderService: tenderService, LotRepo: lotRepo, Sealer: sealer, Authorizer: authorizer}
 }
 
 // CreateProposal создает новое предложение.
 // @Summary Создание предложения
-// @Description Создает новое предложение от имени пользователя, проверяя принадлежность пользователя к организации
+// @Description Создает новое предложение от имени пользователя, проверяя принадлежность пользователя к организации. Цена обязательна, если у тендера задан бюджет, и должна быть в его пределах и валюте; у предложений на аукцион цену задают только ставки аукциона. На приватный тендер предложения подают только приглашенные. Предложение на тендер с лотами указывает открытые лоты в lot_ids, и его цена проверяется по сумме их бюджетов. Содержимое запечатанного (sealed) предложения хранится зашифрованным до вскрытия предложений
 // @Tags Proposals
 // @Accept  json
 // @Produce  json
 // @Param proposal body models.Proposal true "Данные предложения"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
 // @Success 200 {object} models.Proposal "Предложение успешно создано"
-// @Failure 400 {string} string "Неверные данные или пользователь не принадлежит организации"
+// @Failure 400 {string} string "Неверные данные"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Срок приема предложений истек или запрос с этим ключом идемпотентности еще выполняется"
+// @Failure 422 {string} string "Ключ идемпотентности уже использован с другим запросом"
 // @Failure 500 {string} string "Ошибка при создании предложения"
 // @Router /api/bids/new [post]
 func (h *ProposalHandler) CreateProposal(w http.ResponseWriter, r *http.Request) {
@@ -37,13 +53,42 @@ func (h *ProposalHandler) CreateProposal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	status, err := h.ProposalRepo.CheckUserBelongsToOrganizationByID(proposal.OrganizationID, proposal.AuthorID)
-	if err != nil && !status {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	proposal.AuthorID = callerID(r, proposal.AuthorID)
+
+	responsible := h.Authorizer.authorizeUserID(w, r, proposal.OrganizationID, proposal.AuthorID, models.PermissionManageBids)
+	if responsible == nil {
+		return
+	}
+
+	tender := h.tenderAcceptingBids(w, r, proposal.TenderID, responsible.Username)
+	if tender == nil {
+		return
+	}
+
+	if tender.Kind == models.TenderAuction && proposal.Price != nil {
+		http.Error(w, "prices of bids on auction tenders are set by auction bids", http.StatusBadRequest)
+		return
+	}
+
+	tender = h.checkLots(w, r, tender, proposal.LotIDs)
+	if tender == nil {
+		return
+	}
+
+	if !checkPrice(w, tender, proposal.Price, &proposal.Currency) {
 		return
 	}
 
-	if err := h.ProposalRepo.CreateProposal(&proposal); err != nil {
+	// The response of a sealed bid stays sealed too, since idempotent replays keep it in the clear.
+	if tender.BidsSealed() {
+		if err := h.Sealer.Seal(&proposal); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	if err := h.ProposalRepo.CreateProposal(ctx, &proposal); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -55,39 +100,51 @@ func (h *ProposalHandler) CreateProposal(w http.ResponseWriter, r *http.Request)
 
 // GetMyProposals возвращает список предложений для конкретного пользователя.
 // @Summary Получение предложений пользователя
-// @Description Возвращает предложения, связанные с указанным пользователем
+// @Description Возвращает предложения, связанные с указанным пользователем, включая содержимое еще не вскрытых запечатанных предложений
 // @Tags Proposals
 // @Produce  json
-// @Param username query string true "Имя пользователя"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {array} models.Proposal "Список предложений пользователя"
 // @Failure 400 {string} string "Имя пользователя отсутствует"
 // @Failure 500 {string} string "Ошибка при получении предложений"
 // @Router /api/bids/my [get]
 func (h *ProposalHandler) GetMyProposals(w http.ResponseWriter, r *http.Request) {
-	username := r.URL.Query().Get("username")
+	username := callerUsername(r, r.URL.Query().Get("username"))
 	if username == "" {
 		http.Error(w, "username is required", http.StatusBadRequest)
 		return
 	}
 
-	proposals, err := h.ProposalRepo.GetProposalsByUsername(username)
+	proposals, err := h.ProposalRepo.GetProposalsByUsername(r.Context(), username)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	for i := range proposals {
+		if err := h.Sealer.Open(&proposals[i]); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(proposals)
 }
 
 // GetProposalsByTender возвращает список предложений для указанного тендера.
 // @Summary Получение предложений по тендеру
-// @Description Возвращает список всех предложений, связанных с указанным тендером
+// @Description Возвращает список всех предложений, связанных с указанным тендером. Предложения без цены при сортировке по цене идут последними. Содержимое запечатанных (sealed) предложений скрыто до их вскрытия
 // @Tags Proposals
 // @Produce  json
 // @Param tenderId path string true "ID тендера"
+// @Param sort query string false "Порядок: price_asc или price_desc (по умолчанию по дате создания)"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {array} models.Proposal "Список предложений для указанного тендера"
-// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 400 {string} string "Неверный ID тендера или порядок сортировки"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
 // @Failure 500 {string} string "Ошибка при получении предложений"
 // @Router /api/bids/{tenderId}/list [get]
 func (h *ProposalHandler) GetProposalsByTender(w http.ResponseWriter, r *http.Request) {
@@ -98,7 +155,29 @@ func (h *ProposalHandler) GetProposalsByTender(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	proposals, err := h.ProposalRepo.GetProposalsByTender(tenderID)
+	sort := models.ProposalSort(r.URL.Query().Get("sort"))
+	if !sort.IsValid() {
+		http.Error(w, "sort must be one of price_asc, price_desc", http.StatusBadRequest)
+		return
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if h.Authorizer.authorize(w, r, tender.OrganizationID, username, models.PermissionViewBids) == nil {
+		return
+	}
+
+	proposals, err := h.ProposalRepo.GetProposalsByTender(r.Context(), tenderID, sort)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -110,14 +189,19 @@ func (h *ProposalHandler) GetProposalsByTender(w http.ResponseWriter, r *http.Re
 
 // EditProposal редактирует существующее предложение по его ID.
 // @Summary Редактирование предложения
-// @Description Редактирует предложение по указанному ID
+// @Description Редактирует предложение по указанному ID. Цена меняется, только если передана, и должна укладываться в бюджет тендера; цену предложения на аукцион меняют только ставки. Запечатанные (sealed) предложения можно редактировать только до срока приема предложений. Отредактированное предложение относится к текущей версии тендера и перестает быть устаревшим
 // @Tags Proposals
 // @Accept json
 // @Produce json
 // @Param bidId path string true "ID предложения"
 // @Param proposal body models.Proposal true "Данные для обновления предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {object} models.Proposal "Обновленное предложение"
 // @Failure 400 {string} string "Неверный ID предложения или некорректные данные"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 409 {string} string "Срок приема предложений на закрытый тендер истек"
 // @Failure 500 {string} string "Ошибка при редактировании предложения"
 // @Router /api/bids/{bidId}/edit [patch]
 func (h *ProposalHandler) EditProposal(w http.ResponseWriter, r *http.Request) {
@@ -134,13 +218,74 @@ func (h *ProposalHandler) EditProposal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	proposal := h.authorizeProposal(w, r, bidID, models.PermissionManageBids)
+	if proposal == nil {
+		return
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), proposal.TenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sealed := tender.BidsSealed()
+	if sealed && !tender.AcceptsBids(time.Now()) {
+		http.Error(w, "submission deadline has passed", http.StatusConflict)
+		return
+	}
+
+	if err := h.Sealer.Open(proposal); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if tender.Kind == models.TenderAuction && updatedProposal.Price != nil {
+		http.Error(w, "prices of bids on auction tenders are set by auction bids", http.StatusBadRequest)
+		return
+	}
+
+	price, currency := updatedProposal.Price, updatedProposal.Currency
+	if price == nil {
+		price, currency = proposal.Price, proposal.Currency
+	}
+
+	lotIDs := updatedProposal.LotIDs
+	if lotIDs == nil {
+		lotIDs = proposal.LotIDs
+	}
+
+	tender = h.checkLots(w, r, tender, lotIDs)
+	if tender == nil {
+		return
+	}
+
+	if !checkPrice(w, tender, price, &currency) {
+		return
+	}
+
 	updatedProposal.ID = bidID
+	updatedProposal.Price = price
+	updatedProposal.Currency = currency
+	updatedProposal.TenderID = proposal.TenderID
+	updatedProposal.OrganizationID = proposal.OrganizationID
+	updatedProposal.AuthorID = proposal.AuthorID
+
+	content := updatedProposal.Content()
+	if sealed {
+		if err := h.Sealer.Seal(&updatedProposal); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
-	if err := h.ProposalRepo.EditProposal(&updatedProposal); err != nil {
+	if err := h.ProposalRepo.EditProposal(r.Context(), &updatedProposal); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	updatedProposal.SetContent(content)
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(updatedProposal)
 }
@@ -152,8 +297,12 @@ func (h *ProposalHandler) EditProposal(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param bidId path string true "ID предложения"
 // @Param version path int true "Версия предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {object} models.Proposal "Откатанное предложение"
 // @Failure 400 {string} string "Неверный ID предложения или версия"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
 // @Failure 500 {string} string "Ошибка при откате предложения"
 // @Router /api/bids/{bidId}/rollback/{version} [put]
 func (h *ProposalHandler) RollbackProposal(w http.ResponseWriter, r *http.Request) {
@@ -172,7 +321,11 @@ func (h *ProposalHandler) RollbackProposal(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	rolledBackProposal, err := h.ProposalRepo.RollbackProposal(bidID, version)
+	if h.authorizeProposal(w, r, bidID, models.PermissionManageBids) == nil {
+		return
+	}
+
+	rolledBackProposal, err := h.ProposalRepo.RollbackProposal(r.Context(), bidID, version)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -187,8 +340,13 @@ func (h *ProposalHandler) RollbackProposal(w http.ResponseWriter, r *http.Reques
 // @Description Делает предложение доступным для ответственных за организацию и автора
 // @Tags Proposals
 // @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {string} string "Предложение успешно опубликовано"
 // @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение или тендер не найдены"
+// @Failure 409 {string} string "Срок приема предложений истек"
 // @Failure 500 {string} string "Ошибка при публикации предложения"
 // @Router /api/bids/{bidId}/publish [put]
 func (h *ProposalHandler) PublishProposal(w http.ResponseWriter, r *http.Request) {
@@ -199,7 +357,17 @@ func (h *ProposalHandler) PublishProposal(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = h.ProposalRepo.PublishProposal(proposalID)
+	proposal := h.authorizeProposal(w, r, proposalID, models.PermissionManageBids)
+	if proposal == nil {
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if h.tenderAcceptingBids(w, r, proposal.TenderID, username) == nil {
+		return
+	}
+
+	err = h.ProposalRepo.PublishProposal(r.Context(), proposalID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -214,8 +382,12 @@ func (h *ProposalHandler) PublishProposal(w http.ResponseWriter, r *http.Request
 // @Description Делает предложение видимым только автору и ответственным за организацию
 // @Tags Proposals
 // @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {string} string "Предложение успешно отменено"
 // @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
 // @Failure 500 {string} string "Ошибка при отмене предложения"
 // @Router /api/bids/{bidId}/cancel [put]
 func (h *ProposalHandler) CancelProposal(w http.ResponseWriter, r *http.Request) {
@@ -226,7 +398,11 @@ func (h *ProposalHandler) CancelProposal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.ProposalRepo.CancelProposal(proposalID)
+	if h.authorizeProposal(w, r, proposalID, models.PermissionManageBids) == nil {
+		return
+	}
+
+	err = h.ProposalRepo.CancelProposal(r.Context(), proposalID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -236,6 +412,151 @@ func (h *ProposalHandler) CancelProposal(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte("Предложение успешно отменено"))
 }
 
+// AcknowledgeAmendment подтверждает изменения тендера.
+// @Summary Подтверждение изменений тендера
+// @Description Автор устаревшего предложения подтверждает, что предложение остается в силе для текущей версии тендера, без его редактирования. После этого предложение можно одобрить
+// @Tags Proposals
+// @Produce json
+// @Param bidId path string true "ID предложения"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.Proposal "Предложение"
+// @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 409 {string} string "Предложение не устарело, отменено или по нему принято решение"
+// @Failure 500 {string} string "Ошибка при подтверждении изменений"
+// @Router /api/bids/{bidId}/acknowledge_amendment [put]
+func (h *ProposalHandler) AcknowledgeAmendment(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	proposal := h.authorizeProposal(w, r, proposalID, models.PermissionManageBids)
+	if proposal == nil {
+		return
+	}
+
+	if proposal.Status != "CREATED" && proposal.Status != "PUBLISHED" {
+		http.Error(w, "amendments are only acknowledged on draft and published proposals", http.StatusConflict)
+		return
+	}
+
+	if !proposal.Stale {
+		http.Error(w, "proposal refers to the current version of the tender", http.StatusConflict)
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), callerUsername(r, r.URL.Query().Get("username")))
+	proposal, err = h.ProposalRepo.AcknowledgeAmendment(ctx, proposalID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(proposal)
+}
+
+// SubmitDecision принимает решение по предложению.
+// @Summary Отправка решения по предложению
+// @Description Решение принимают ответственные за организацию тендера. Одно отклонение отклоняет предложение; при согласовании кворумом min(3, число ответственных с правом решения) предложение согласуется, а тендер закрывается. Устаревшее предложение (поданное на прежнюю версию тендера) нельзя одобрить, пока автор не подтвердит изменения или не отредактирует его
+// @Tags Proposals
+// @Produce json
+// @Param bidId path string true "ID предложения"
+// @Param decision query string true "Решение: Approved или Rejected"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.Proposal "Предложение после решения"
+// @Failure 400 {string} string "Неверный ID предложения или решение"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 409 {string} string "Предложение не опубликовано или устарело, тендер закрыт или предложения еще не вскрыты"
+// @Failure 500 {string} string "Ошибка при сохранении решения"
+// @Router /api/bids/{bidId}/submit_decision [put]
+func (h *ProposalHandler) SubmitDecision(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	decision := models.Decision(r.URL.Query().Get("decision"))
+	if !decision.IsValid() {
+		http.Error(w, "decision must be Approved or Rejected", http.StatusBadRequest)
+		return
+	}
+
+	responsible := h.authorizeTenderResponsible(w, r, proposalID, models.PermissionDecideBids)
+	if responsible == nil {
+		return
+	}
+
+	proposal, err := h.ProposalRepo.SubmitDecision(r.Context(), proposalID, responsible.UserID, decision)
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(proposal)
+}
+
+// LeaveFeedback оставляет отзыв на предложение.
+// @Summary Отзыв на предложение
+// @Description Отзыв оставляют ответственные за организацию тендера
+// @Tags Proposals
+// @Produce json
+// @Param bidId path string true "ID предложения"
+// @Param bidFeedback query string true "Текст отзыва"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.ProposalFeedback "Оставленный отзыв"
+// @Failure 400 {string} string "Неверный ID предложения или отзыв"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 500 {string} string "Ошибка при сохранении отзыва"
+// @Router /api/bids/{bidId}/feedback [put]
+func (h *ProposalHandler) LeaveFeedback(w http.ResponseWriter, r *http.Request) {
+	proposalID, err := uuid.Parse(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "invalid proposal ID", http.StatusBadRequest)
+		return
+	}
+
+	description := r.URL.Query().Get("bidFeedback")
+	if description == "" || len([]rune(description)) > maxFeedbackLength {
+		http.Error(w, "bidFeedback is required and must be at most 1000 characters", http.StatusBadRequest)
+		return
+	}
+
+	responsible := h.authorizeTenderResponsible(w, r, proposalID, models.PermissionLeaveFeedback)
+	if responsible == nil {
+		return
+	}
+
+	feedback := models.ProposalFeedback{
+		ProposalID:  proposalID,
+		AuthorID:    responsible.UserID,
+		Description: description,
+	}
+
+	if err := h.ProposalRepo.AddFeedback(r.Context(), &feedback); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feedback)
+}
+
 // GetProposalStatus возвращает статус предложения по его ID.
 // @Summary Получение статуса предложения
 // @Description Возвращает текущий статус предложения
@@ -258,7 +579,7 @@ func (h *ProposalHandler) GetProposalStatus(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	status, err := h.ProposalRepo.GetProposalStatus(proposalID)
+	status, err := h.ProposalRepo.GetProposalStatus(r.Context(), proposalID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -267,3 +588,165 @@ func (h *ProposalHandler) GetProposalStatus(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(status))
 }
+
+// authorizeProposal loads the proposal and checks the caller's permission in the proposal's organization.
+// On failure it writes the error response and returns nil.
+func (h *ProposalHandler) authorizeProposal(w http.ResponseWriter, r *http.Request, proposalID uuid.UUID, permission models.Permission) *models.Proposal {
+	proposal, err := h.ProposalRepo.GetProposalByID(r.Context(), proposalID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "proposal not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if h.Authorizer.authorize(w, r, proposal.OrganizationID, username, permission) == nil {
+		return nil
+	}
+
+	return proposal
+}
+
+// tenderAcceptingBids loads the tender and checks that it is visible to the bidder and still accepts bids.
+// On failure it writes the error response and returns nil.
+func (h *ProposalHandler) tenderAcceptingBids(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID, username string) *models.Tender {
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return nil
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	if !tender.AcceptsBids(time.Now()) {
+		http.Error(w, "submission deadline has passed", http.StatusConflict)
+		return nil
+	}
+
+	return tender
+}
+
+// checkLots checks that a bid on a tender with lots is for open lots of the tender and returns the tender
+// with the budget of those lots. On failure it writes the error response and returns nil.
+func (h *ProposalHandler) checkLots(w http.ResponseWriter, r *http.Request, tender *models.Tender, lotIDs models.LotIDs) *models.Tender {
+	lots, err := h.LotRepo.GetLots(r.Context(), tender.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	if len(lots) == 0 {
+		if len(lotIDs) > 0 {
+			http.Error(w, "tender has no lots", http.StatusBadRequest)
+			return nil
+		}
+
+		return tender
+	}
+
+	if len(lotIDs) == 0 {
+		http.Error(w, "lot_ids is required for tenders with lots", http.StatusBadRequest)
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]models.TenderLot, len(lots))
+	for _, lot := range lots {
+		byID[lot.ID] = lot
+	}
+
+	targeted := make([]models.TenderLot, 0, len(lotIDs))
+	for _, lotID := range lotIDs {
+		lot, ok := byID[lotID]
+		if !ok {
+			http.Error(w, "lot "+lotID.String()+" does not belong to the tender or is duplicated", http.StatusBadRequest)
+			return nil
+		}
+
+		if lot.Status != models.LotOpen {
+			http.Error(w, "lot "+lot.Title+" is already awarded", http.StatusConflict)
+			return nil
+		}
+
+		delete(byID, lotID)
+		targeted = append(targeted, lot)
+	}
+
+	return tender.ForLots(targeted)
+}
+
+// checkPrice fills the currency of the price from the tender if it is not set and checks that
+// the price fits the tender budget. On failure it writes the error response and returns false.
+// Prices of bids on auction tenders are set and checked by the auction instead.
+func checkPrice(w http.ResponseWriter, tender *models.Tender, price *models.Amount, currency *models.Currency) bool {
+	if tender.Kind == models.TenderAuction {
+		return true
+	}
+
+	if price != nil && *currency == "" {
+		*currency = tender.Currency
+	}
+
+	if err := tender.CheckPrice(price, *currency); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// authorizeTenderResponsible checks the caller's permission in the organization of the tender
+// the proposal was submitted to. On failure it writes the error response and returns nil.
+func (h *ProposalHandler) authorizeTenderResponsible(w http.ResponseWriter, r *http.Request, proposalID uuid.UUID, permission models.Permission) *models.OrganizationResponsible {
+	proposal, err := h.ProposalRepo.GetProposalByID(r.Context(), proposalID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "proposal not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), proposal.TenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	return h.Authorizer.authorize(w, r, tender.OrganizationID, username, permission)
+}
+
+// loadProposal loads the proposal and its tender. On failure it writes the error response and returns nil.
+func loadProposal(w http.ResponseWriter, r *http.Request, proposals _interface.ProposalRepository, tenders _interface.TenderService, proposalID uuid.UUID) (*models.Proposal, *models.Tender) {
+	proposal, err := proposals.GetProposalByID(r.Context(), proposalID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "proposal not found", http.StatusNotFound)
+		return nil, nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil
+	}
+
+	tender, err := tenders.GetTenderByID(r.Context(), proposal.TenderID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, nil
+	}
+
+	return proposal, tender
+}
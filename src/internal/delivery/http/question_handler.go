@@ -0,0 +1,251 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const (
+	maxQuestionLength      = 2000
+	maxAnswerLength        = 4000
+	maxAmendmentNoteLength = 1000
+)
+
+type QuestionHandler struct {
+	QuestionRepo  _interface.QuestionRepository
+	TenderService _interface.TenderService
+	Authorizer    *Authorizer
+}
+
+func NewQuestionHandler(questionRepo _interface.QuestionRepository, tenderService _interface.TenderService, authorizer *Authorizer) *QuestionHandler {
+	return &QuestionHandler{QuestionRepo: questionRepo, TenderService: tenderService, Authorizer: authorizer}
+}
+
+// AskQuestion задает вопрос по тендеру.
+// @Summary Вопрос по тендеру
+// @Description Задает вопрос по опубликованному тендеру от имени организации-участника до срока приема предложений. Спросившая организация и сотрудник не раскрываются: после ответа вопрос видят все, кому виден тендер
+// @Tags Questions
+// @Accept  json
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param question body models.AskQuestionRequest true "Организация и текст вопроса"
+// @Success 200 {object} models.TenderQuestion "Вопрос"
+// @Failure 400 {string} string "Неверный ID тендера или данные"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Тендер не принимает вопросы"
+// @Failure 500 {string} string "Ошибка при создании вопроса"
+// @Router /api/tenders/{tenderId}/questions [post]
+func (h *QuestionHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.AskQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request.Question = strings.TrimSpace(request.Question)
+	if request.Question == "" || len([]rune(request.Question)) > maxQuestionLength {
+		http.Error(w, "question is required and must be at most 2000 characters", http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, request.OrganizationID, username, models.PermissionManageBids)
+	if responsible == nil {
+		return
+	}
+
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, responsible.Username) {
+		return
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if tender.OrganizationID == request.OrganizationID {
+		http.Error(w, "organization can't ask questions on its own tender", http.StatusBadRequest)
+		return
+	}
+
+	if tender.Status != "PUBLISHED" || !tender.AcceptsBids(time.Now()) {
+		http.Error(w, "tender does not accept questions", http.StatusConflict)
+		return
+	}
+
+	question := models.TenderQuestion{
+		TenderID:       tenderID,
+		OrganizationID: request.OrganizationID,
+		AskedBy:        &responsible.UserID,
+		Question:       request.Question,
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	if err := h.QuestionRepo.CreateQuestion(ctx, &question); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(question)
+}
+
+// GetQuestions возвращает вопросы по тендеру.
+// @Summary Вопросы по тендеру
+// @Description Возвращает вопросы с ответами по тендеру, старые первыми. Вопросы без ответа видят только ответственные заказчика и спросившей организации
+// @Tags Questions
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param limit query int false "Максимальное число вопросов"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} models.TenderQuestion "Вопросы"
+// @Failure 400 {string} string "Неверный ID тендера или пагинация"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка при получении вопросов"
+// @Router /api/tenders/{tenderId}/questions [get]
+func (h *QuestionHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return
+	}
+
+	questions, err := h.QuestionRepo.GetQuestions(r.Context(), tenderID, username, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(questions)
+}
+
+// AnswerQuestion отвечает на вопрос по тендеру.
+// @Summary Ответ на вопрос
+// @Description Отвечает на вопрос по опубликованному тендеру. Если ответ меняет условия тендера, в amendment_note описывается изменение, и ответ создает новую версию тендера. Участники тендера получают уведомление tender.question_answered
+// @Tags Questions
+// @Accept  json
+// @Produce  json
+// @Param tenderId path string true "ID тендера"
+// @Param questionId path string true "ID вопроса"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param answer body models.AnswerQuestionRequest true "Ответ и описание изменения тендера"
+// @Success 200 {object} models.TenderQuestion "Вопрос с ответом"
+// @Failure 400 {string} string "Неверный ID или данные"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер или вопрос не найдены"
+// @Failure 409 {string} string "Вопрос уже отвечен или тендер не опубликован"
+// @Failure 500 {string} string "Ошибка при ответе на вопрос"
+// @Router /api/tenders/{tenderId}/questions/{questionId}/answer [put]
+func (h *QuestionHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenderID, err := uuid.Parse(vars["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	questionID, err := uuid.Parse(vars["questionId"])
+	if err != nil {
+		http.Error(w, "invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.AnswerQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request.Answer = strings.TrimSpace(request.Answer)
+	if request.Answer == "" || len([]rune(request.Answer)) > maxAnswerLength {
+		http.Error(w, "answer is required and must be at most 4000 characters", http.StatusBadRequest)
+		return
+	}
+
+	if request.AmendmentNote != nil {
+		note := strings.TrimSpace(*request.AmendmentNote)
+		if note == "" || len([]rune(note)) > maxAmendmentNoteLength {
+			http.Error(w, "amendment_note must be non-empty and at most 1000 characters", http.StatusBadRequest)
+			return
+		}
+		request.AmendmentNote = &note
+	}
+
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	responsible := h.Authorizer.authorize(w, r, tender.OrganizationID, username, models.PermissionManageTenders)
+	if responsible == nil {
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	question, err := h.QuestionRepo.AnswerQuestion(ctx, tenderID, questionID, request, responsible.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "question not found", http.StatusNotFound)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(question)
+}
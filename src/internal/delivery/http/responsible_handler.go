@@ -0,0 +1,226 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+// GetResponsibles возвращает ответственных за организацию.
+// @Summary Получение ответственных за организацию
+// @Description Возвращает ответственных за организацию, их роли и кто выдал им доступ. Доступно только ответственным
+// @Tags Organizations
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.OrganizationResponsible "Список ответственных"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 500 {string} string "Ошибка при получении ответственных"
+// @Router /api/organizations/{organizationId}/responsibles [get]
+func (h *OrganizationHandler) GetResponsibles(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeOrganization(w, r, orgID, models.PermissionViewResponsibles) == nil {
+		return
+	}
+
+	responsibles, err := h.OrganizationRepo.GetResponsibles(r.Context(), orgID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(responsibles)
+}
+
+// AddResponsible назначает сотрудника ответственным за организацию.
+// @Summary Добавление ответственного
+// @Description Назначает сотрудника ответственным с ролью (по умолчанию viewer). Пользователь может быть ответственным только в одной организации. Доступно только владельцам
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param responsible body models.AddResponsibleRequest true "Сотрудник"
+// @Success 200 {object} models.OrganizationResponsible "Новый ответственный"
+// @Failure 400 {string} string "Неверный ID организации или данные"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Сотрудник не найден"
+// @Failure 409 {string} string "Сотрудник уже является ответственным"
+// @Failure 500 {string} string "Ошибка при добавлении ответственного"
+// @Router /api/organizations/{organizationId}/responsibles/new [post]
+func (h *OrganizationHandler) AddResponsible(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.AddResponsibleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if request.Username == "" {
+		http.Error(w, "username is required", http.StatusBadRequest)
+		return
+	}
+
+	if request.Role == "" {
+		request.Role = models.RoleViewer
+	}
+
+	if !request.Role.IsValid() {
+		http.Error(w, "invalid role", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeOrganization(w, r, orgID, models.PermissionManageResponsibles) == nil {
+		return
+	}
+
+	grantedBy := callerUsername(r, r.URL.Query().Get("username"))
+	responsible, err := h.OrganizationRepo.AddResponsible(r.Context(), orgID, request.Username, request.Role, grantedBy)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee not found", http.StatusNotFound)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrAlreadyExists {
+		http.Error(w, "employee is already responsible for an organization", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(responsible)
+}
+
+// RemoveResponsible снимает сотрудника с ответственных за организацию.
+// @Summary Удаление ответственного
+// @Description Снимает сотрудника с ответственных. Последнего ответственного и последнего владельца удалить нельзя. Доступно только владельцам
+// @Tags Organizations
+// @Param organizationId path string true "ID организации"
+// @Param responsibleUsername path string true "Имя пользователя ответственного"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {string} string "Ответственный успешно удален"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Сотрудник не является ответственным за организацию"
+// @Failure 409 {string} string "Нельзя удалить последнего ответственного или владельца"
+// @Failure 500 {string} string "Ошибка при удалении ответственного"
+// @Router /api/organizations/{organizationId}/responsibles/{responsibleUsername} [delete]
+func (h *OrganizationHandler) RemoveResponsible(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	orgID, err := uuid.Parse(vars["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeOrganization(w, r, orgID, models.PermissionManageResponsibles) == nil {
+		return
+	}
+
+	err = h.OrganizationRepo.RemoveResponsible(r.Context(), orgID, vars["responsibleUsername"])
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee is not responsible for the organization", http.StatusNotFound)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrLastResponsible || errors.Cause(err) == models.ErrLastOwner {
+		http.Error(w, errors.Cause(err).Error(), http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Ответственный успешно удален"))
+}
+
+// ChangeResponsibleRole меняет роль ответственного.
+// @Summary Изменение роли ответственного
+// @Description Меняет роль ответственного: owner, tender_manager, bid_manager, reviewer или viewer. Последнего владельца понизить нельзя. Доступно только владельцам
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param responsibleUsername path string true "Имя пользователя ответственного"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param role body models.ChangeRoleRequest true "Новая роль"
+// @Success 200 {object} models.OrganizationResponsible "Ответственный с новой ролью"
+// @Failure 400 {string} string "Неверный ID организации или роль"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Сотрудник не является ответственным за организацию"
+// @Failure 409 {string} string "Нельзя понизить последнего владельца"
+// @Failure 500 {string} string "Ошибка при изменении роли"
+// @Router /api/organizations/{organizationId}/responsibles/{responsibleUsername}/role [put]
+func (h *OrganizationHandler) ChangeResponsibleRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	orgID, err := uuid.Parse(vars["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.ChangeRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !request.Role.IsValid() {
+		http.Error(w, "invalid role", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeOrganization(w, r, orgID, models.PermissionManageResponsibles) == nil {
+		return
+	}
+
+	responsible, err := h.OrganizationRepo.ChangeResponsibleRole(r.Context(), orgID, vars["responsibleUsername"], request.Role)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "employee is not responsible for the organization", http.StatusNotFound)
+		return
+	}
+
+	if errors.Cause(err) == models.ErrLastOwner {
+		http.Error(w, models.ErrLastOwner.Error(), http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(responsible)
+}
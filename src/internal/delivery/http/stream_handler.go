@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/events"
+)
+
+// streamHeartbeat keeps idle event streams open through proxies.
+const streamHeartbeat = 15 * time.Second
+
+type StreamHandler struct {
+	Hub          *events.StreamHub
+	EmployeeRepo _interface.EmployeeRepository
+}
+
+func NewStreamHandler(hub *events.StreamHub, employeeRepo _interface.EmployeeRepository) *StreamHandler {
+	return &StreamHandler{Hub: hub, EmployeeRepo: employeeRepo}
+}
+
+// StreamEvents отправляет изменения тендеров и предложений в формате Server-Sent Events.
+// @Summary Поток событий
+// @Description Держит соединение открытым и отправляет события, доступные пользователю: публикацию тендеров, смену статуса тендеров, в которых участвует организация пользователя, новые предложения на тендеры организации и решения по предложениям пользователя. Имя события — тип доменного события, данные — событие в JSON
+// @Tags Events
+// @Produce  text/event-stream
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {object} models.DomainEvent "Поток событий"
+// @Failure 401 {string} string "Пользователь не указан или не найден"
+// @Failure 500 {string} string "Ошибка при открытии потока"
+// @Router /api/events/stream [get]
+func (h *StreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	userID, ok := callerEmployeeID(w, r, h.EmployeeRepo)
+	if !ok {
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	subscription := h.Hub.Subscribe(userID)
+	defer h.Hub.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case event, open := <-subscription.Events:
+			if !open {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -1,23 +1,30 @@
 package http
 
 import (
+	"database/sql"
 	"encoding/json"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"avito_2024/src/internal/audit"
 	"avito_2024/src/internal/domain/interface"
 	"avito_2024/src/internal/domain/models"
+	"avito_2024/src/internal/sealing"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 type TenderHandler struct {
 	TenderService _interface.TenderService
+	Opener        *sealing.Opener
+	Authorizer    *Authorizer
 }
 
-func NewTenderHandler(tenderService _interface.TenderService) *TenderHandler {
-	return &TenderHandler{TenderService: tenderService}
+func NewTenderHandler(tenderService _interface.TenderService, opener *sealing.Opener, authorizer *Authorizer) *TenderHandler {
+	return &TenderHandler{TenderService: tenderService, Opener: opener, Authorizer: authorizer}
 }
 
 // Ping проверяет состояние сервиса.
@@ -35,18 +42,20 @@ func (h *TenderHandler) Ping(w http.ResponseWriter, r *http.Request) {
 
 // GetTenders получает список тендеров по типу сервиса.
 // @Summary Получить список тендеров
-// @Description Возвращает список тендеров по переданному типу сервиса
+// @Description Возвращает список тендеров по переданному типу сервиса. Приватные тендеры видны только ответственным заказчика и приглашенным
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
 // @Param serviceType query string false "Тип сервиса"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {array} models.Tender "Список тендеров"
 // @Failure 500 {string} string "Ошибка сервиса"
 // @Router /api/tenders [get]
 func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
 	serviceType := r.URL.Query().Get("serviceType")
+	username := callerUsername(r, r.URL.Query().Get("username"))
 
-	tenders, err := h.TenderService.GetTenders(serviceType)
+	tenders, err := h.TenderService.GetTenders(r.Context(), serviceType, username)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -58,13 +67,18 @@ func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
 
 // CreateTender создает новый тендер.
 // @Summary Создать новый тендер
-// @Description Создает новый тендер на основе переданных данных
+// @Description Создает новый тендер на основе переданных данных. Сроки приема предложений и принятия решения необязательны и должны быть в будущем; бюджет задается вместе с валютой. Если sealed = true, предложения запечатываются: их содержимое скрыто до срока приема предложений или ручного вскрытия, а срок решения должен быть позже срока приема. Тендер с kind = AUCTION проводится как аукцион на понижение: нужны budgetMax (начальная цена), minDecrement, roundDuration, snipingExtension и auctionStartsAt, сроки и запечатывание не допускаются. Тендер с visibility = PRIVATE видят и получают предложения только приглашенные организации и сотрудники; видимость задается при создании
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
 // @Param tender body models.Tender true "Тендер"
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасного повтора запроса"
 // @Success 200 {object} models.Tender "Созданный тендер"
 // @Failure 400 {string} string "Ошибка валидации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 409 {string} string "Запрос с этим ключом идемпотентности еще выполняется"
+// @Failure 422 {string} string "Ключ идемпотентности уже использован с другим запросом"
 // @Failure 500 {string} string "Ошибка сервиса"
 // @Router /api/tenders/new [post]
 func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
@@ -74,13 +88,43 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	status, err := h.TenderService.CheckUserBelongsToOrganization(tender.OrganizationID, tender.CreatorUsername)
-	if err != nil || !status {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := validateDeadlines(tender.SubmissionDeadline, tender.DecisionDeadline, tender.Sealed, nil, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := tender.ValidateBudget(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	tenderResult, err := h.TenderService.CreateTender(&tender)
+	if tender.Kind == "" {
+		tender.Kind = models.TenderStandard
+	}
+
+	if err := tender.ValidateAuction(time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if tender.Visibility == "" {
+		tender.Visibility = models.VisibilityPublic
+	}
+
+	if !tender.Visibility.IsValid() {
+		http.Error(w, "visibility must be PUBLIC or PRIVATE", http.StatusBadRequest)
+		return
+	}
+
+	tender.CreatorUsername = callerUsername(r, tender.CreatorUsername)
+
+	responsible := h.Authorizer.authorize(w, r, tender.OrganizationID, tender.CreatorUsername, models.PermissionManageTenders)
+	if responsible == nil {
+		return
+	}
+
+	ctx := audit.WithActor(r.Context(), responsible.Username)
+	tenderResult, err := h.TenderService.CreateTender(ctx, &tender)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -97,13 +141,13 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
-// @Param username query string true "Имя пользователя"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {array} models.Tender "Список тендеров"
 // @Failure 500 {string} string "Ошибка сервиса"
 // @Router /api/tenders/my [get]
 func (h *TenderHandler) GetMyTenders(w http.ResponseWriter, r *http.Request) {
-	username := r.URL.Query().Get("username")
-	tenders, err := h.TenderService.GetMyTenders(username)
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	tenders, err := h.TenderService.GetMyTenders(r.Context(), username)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -115,14 +159,18 @@ func (h *TenderHandler) GetMyTenders(w http.ResponseWriter, r *http.Request) {
 
 // EditTender редактирует существующий тендер по его ID.
 // @Summary Редактировать тендер
-// @Description Обновляет информацию о тендере по переданным данным и ID
+// @Description Обновляет информацию о тендере по переданным данным и ID. Сроки, бюджет и валюта меняются, только если переданы. Тип тендера и настройки аукциона не меняются; бюджет аукциона нельзя менять после его начала. Изменение опубликованного тендера создает новую версию, на которую должны перейти уже поданные предложения: их авторы получают уведомление bid.stale
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
 // @Param tenderID path string true "ID тендера"  // Передаем ID тендера через URL
 // @Param updatedTender body models.Tender true "Обновленный тендер"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {object} models.Tender "Обновленный тендер"
 // @Failure 400 {string} string "Ошибка валидации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
 // @Failure 500 {string} string "Ошибка сервиса"
 // @Router /api/tenders/{tenderID}/edit [patch]
 func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
@@ -140,8 +188,28 @@ func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	stored := h.authorizeTender(w, r, id, models.PermissionManageTenders)
+	if stored == nil {
+		return
+	}
+
+	if err := validateDeadlines(updatedTender.SubmissionDeadline, updatedTender.DecisionDeadline, stored.Sealed, stored, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateBudget(&updatedTender, stored); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validateAuctionEdit(&updatedTender, stored, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	updatedTender.ID = id
-	err = h.TenderService.EditTender(&updatedTender)
+	err = h.TenderService.EditTender(r.Context(), &updatedTender)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -159,8 +227,12 @@ func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param tenderId path string true "ID тендера"
 // @Param version path int true "Версия тендера для отката"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {object} models.Tender "Откатанный тендер"
 // @Failure 400 {string} string "Неверный ID тендера или версия"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
 // @Failure 500 {string} string "Ошибка сервиса"
 // @Router /api/tenders/{tenderId}/rollback/{version} [put]
 func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
@@ -180,7 +252,11 @@ func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rolledBackTender, err := h.TenderService.RollbackTender(id, version)
+	if h.authorizeTender(w, r, id, models.PermissionManageTenders) == nil {
+		return
+	}
+
+	rolledBackTender, err := h.TenderService.RollbackTender(r.Context(), id, version)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -195,8 +271,12 @@ func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 // @Description Публикация тендера, чтобы он стал доступен всем пользователям
 // @Tags Tenders
 // @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {string} string "Тендер успешно опубликован"
 // @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
 // @Failure 500 {string} string "Ошибка при публикации тендера"
 // @Router /api/tenders/{tenderId}/publish [put]
 func (h *TenderHandler) PublishTender(w http.ResponseWriter, r *http.Request) {
@@ -209,7 +289,11 @@ func (h *TenderHandler) PublishTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.TenderService.PublishTender(id)
+	if h.authorizeTender(w, r, id, models.PermissionManageTenders) == nil {
+		return
+	}
+
+	err = h.TenderService.PublishTender(r.Context(), id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -224,8 +308,12 @@ func (h *TenderHandler) PublishTender(w http.ResponseWriter, r *http.Request) {
 // @Description Закрытие тендера, чтобы он стал недоступен для всех пользователей, кроме ответственных
 // @Tags Tenders
 // @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {string} string "Тендер успешно закрыт"
 // @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
 // @Failure 500 {string} string "Ошибка при закрытии тендера"
 // @Router /api/tenders/{tenderId}/close [put]
 func (h *TenderHandler) CloseTender(w http.ResponseWriter, r *http.Request) {
@@ -238,7 +326,11 @@ func (h *TenderHandler) CloseTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.TenderService.CloseTender(id)
+	if h.authorizeTender(w, r, id, models.PermissionCloseTenders) == nil {
+		return
+	}
+
+	err = h.TenderService.CloseTender(r.Context(), id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -248,13 +340,55 @@ func (h *TenderHandler) CloseTender(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("Тендер успешно закрыт"))
 }
 
+// OpenBids вскрывает запечатанные предложения тендера.
+// @Summary Вскрытие предложений
+// @Description Расшифровывает и открывает ответственным содержимое запечатанных (sealed) предложений тендера до срока приема предложений. После вскрытия новые предложения не принимаются. По истечении срока приема предложения вскрываются автоматически
+// @Tags Tenders
+// @Param tenderId path string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {string} string "Предложения вскрыты"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Предложения тендера не запечатаны или уже вскрыты"
+// @Failure 500 {string} string "Ошибка при вскрытии предложений"
+// @Router /api/tenders/{tenderId}/open_bids [put]
+func (h *TenderHandler) OpenBids(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "invalid tender ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeTender(w, r, id, models.PermissionCloseTenders) == nil {
+		return
+	}
+
+	err = h.Opener.OpenBids(r.Context(), id)
+	if errors.Cause(err) == models.ErrInvalidState {
+		http.Error(w, "tender is not sealed or its bids are already opened", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Предложения вскрыты"))
+}
+
 // GetTenderStatus возвращает статус тендера по его ID.
 // @Summary Получение статуса тендера
 // @Description Возвращает текущий статус тендера
 // @Tags Tenders
 // @Param tenderId query string true "ID тендера"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
 // @Success 200 {string} string "Текущий статус тендера"
 // @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
 // @Failure 500 {string} string "Ошибка при получении статуса тендера"
 // @Router /api/tenders/status [get]
 func (h *TenderHandler) GetTenderStatus(w http.ResponseWriter, r *http.Request) {
@@ -270,7 +404,12 @@ func (h *TenderHandler) GetTenderStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	status, err := h.TenderService.GetTenderStatus(tenderID)
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if !checkTenderVisible(w, r, h.TenderService, tenderID, username) {
+		return
+	}
+
+	status, err := h.TenderService.GetTenderStatus(r.Context(), tenderID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -279,3 +418,111 @@ func (h *TenderHandler) GetTenderStatus(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(status))
 }
+
+// authorizeTender loads the tender and checks the caller's permission in its organization.
+// On failure it writes the error response and returns nil.
+func (h *TenderHandler) authorizeTender(w http.ResponseWriter, r *http.Request, tenderID uuid.UUID, permission models.Permission) *models.Tender {
+	tender, err := h.TenderService.GetTenderByID(r.Context(), tenderID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return nil
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	if h.Authorizer.authorize(w, r, tender.OrganizationID, username, permission) == nil {
+		return nil
+	}
+
+	return tender
+}
+
+// checkTenderVisible checks that the user may see the tender. A private tender hidden from the user
+// is reported as not found, so its existence is not disclosed. On failure it writes the error response.
+func checkTenderVisible(w http.ResponseWriter, r *http.Request, tenders _interface.TenderService, tenderID uuid.UUID, username string) bool {
+	visible, err := tenders.CanViewTender(r.Context(), tenderID, username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	if !visible {
+		http.Error(w, "tender not found", http.StatusNotFound)
+		return false
+	}
+
+	return true
+}
+
+// validateDeadlines checks the deadlines sent by the client: new deadlines must be in the future and
+// the decision deadline must not precede the submission one. Bids of a sealed tender open at the
+// submission deadline, so its decision deadline must be later to leave time for decisions.
+// Deadlines that are not sent are taken from stored.
+func validateDeadlines(submission, decision *time.Time, sealed bool, stored *models.Tender, now time.Time) error {
+	if submission != nil && !submission.After(now) {
+		return errors.New("submissionDeadline must be in the future")
+	}
+
+	if decision != nil && !decision.After(now) {
+		return errors.New("decisionDeadline must be in the future")
+	}
+
+	if stored != nil {
+		if submission == nil {
+			submission = stored.SubmissionDeadline
+		}
+		if decision == nil {
+			decision = stored.DecisionDeadline
+		}
+	}
+
+	if submission != nil && decision != nil && decision.Before(*submission) {
+		return errors.New("decisionDeadline must not be before submissionDeadline")
+	}
+
+	if sealed && submission != nil && decision != nil && !decision.After(*submission) {
+		return errors.New("decisionDeadline of a sealed tender must be after submissionDeadline")
+	}
+
+	return nil
+}
+
+// validateBudget checks the budget of the edited tender; fields that are not sent are taken from stored.
+func validateBudget(updated *models.Tender, stored *models.Tender) error {
+	merged := *stored
+	if updated.BudgetMin != nil {
+		merged.BudgetMin = updated.BudgetMin
+	}
+	if updated.BudgetMax != nil {
+		merged.BudgetMax = updated.BudgetMax
+	}
+	if updated.Currency != "" {
+		merged.Currency = updated.Currency
+	}
+
+	return merged.ValidateBudget()
+}
+
+// validateAuctionEdit checks the edit of an auction tender: auctions have no deadlines, and the budget,
+// which holds the starting price, cannot change once the auction has started. The kind and the auction
+// settings themselves are fixed at creation.
+func validateAuctionEdit(updated *models.Tender, stored *models.Tender, now time.Time) error {
+	if stored.Kind != models.TenderAuction {
+		return nil
+	}
+
+	if updated.SubmissionDeadline != nil || updated.DecisionDeadline != nil {
+		return errors.New("auction tenders have no deadlines")
+	}
+
+	budgetChanged := updated.BudgetMin != nil || updated.BudgetMax != nil || updated.Currency != ""
+	if budgetChanged && !now.Before(*stored.AuctionStartsAt) {
+		return errors.New("budget cannot be changed after the auction has started")
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package http
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const (
+	maxWebhookURLLength = 2000
+	maxSecretLength     = 100
+	minSecretLength     = 16
+)
+
+type WebhookHandler struct {
+	WebhookRepo _interface.WebhookRepository
+	Authorizer  *Authorizer
+}
+
+func NewWebhookHandler(webhookRepo _interface.WebhookRepository, authorizer *Authorizer) *WebhookHandler {
+	return &WebhookHandler{WebhookRepo: webhookRepo, Authorizer: authorizer}
+}
+
+// CreateWebhook создает подписку организации на события.
+// @Summary Создание вебхука
+// @Description Подписывает организацию на события тендеров, в которых она участвует. Тело запроса подписывается HMAC-SHA256 с секретом подписки в заголовке X-Webhook-Signature. Секрет возвращается только при создании
+// @Tags Webhooks
+// @Accept  json
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Param webhook body models.CreateWebhookRequest true "Адрес, секрет и типы событий"
+// @Success 200 {object} models.WebhookSubscription "Созданная подписка"
+// @Failure 400 {string} string "Неверные данные подписки"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 500 {string} string "Ошибка при создании подписки"
+// @Router /api/organizations/{organizationId}/webhooks/new [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	var request models.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !isWebhookURL(request.URL) {
+		http.Error(w, "url must be an absolute http or https URL", http.StatusBadRequest)
+		return
+	}
+
+	if len(request.Events) == 0 {
+		http.Error(w, "at least one event type is required", http.StatusBadRequest)
+		return
+	}
+
+	for _, eventType := range request.Events {
+		if !eventType.IsValid() {
+			http.Error(w, "unknown event type "+string(eventType), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if request.Secret != "" && (len(request.Secret) < minSecretLength || len(request.Secret) > maxSecretLength) {
+		http.Error(w, "secret must be from 16 to 100 characters", http.StatusBadRequest)
+		return
+	}
+
+	responsible := h.authorizeWebhooks(w, r, orgID)
+	if responsible == nil {
+		return
+	}
+
+	subscription := models.WebhookSubscription{
+		OrganizationID: orgID,
+		URL:            request.URL,
+		Secret:         request.Secret,
+		EventTypes:     request.Events,
+	}
+
+	if subscription.Secret == "" {
+		if subscription.Secret, err = generateSecret(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err = h.WebhookRepo.CreateSubscription(r.Context(), &subscription, responsible.Username); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscription)
+}
+
+// GetWebhooks возвращает подписки организации.
+// @Summary Получение вебхуков организации
+// @Description Возвращает активные подписки организации без секретов
+// @Tags Webhooks
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.WebhookSubscription "Список подписок"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 500 {string} string "Ошибка при получении подписок"
+// @Router /api/organizations/{organizationId}/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeWebhooks(w, r, orgID) == nil {
+		return
+	}
+
+	subscriptions, err := h.WebhookRepo.GetSubscriptions(r.Context(), orgID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscriptions)
+}
+
+// DeleteWebhook удаляет подписку организации.
+// @Summary Удаление вебхука
+// @Description Прекращает доставку событий подписке; история доставок сохраняется
+// @Tags Webhooks
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param webhookId path string true "ID подписки"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {string} string "Подписка успешно удалена"
+// @Failure 400 {string} string "Неверный ID организации или подписки"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Подписка не найдена"
+// @Failure 500 {string} string "Ошибка при удалении подписки"
+// @Router /api/organizations/{organizationId}/webhooks/{webhookId} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := parseWebhookPath(w, r)
+	if !ok {
+		return
+	}
+
+	if h.authorizeWebhooks(w, r, orgID) == nil {
+		return
+	}
+
+	err := h.WebhookRepo.DeleteSubscription(r.Context(), orgID, webhookID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Подписка успешно удалена"))
+}
+
+// GetWebhookDeliveries возвращает историю доставок подписки.
+// @Summary Получение истории доставок вебхука
+// @Description Возвращает доставки событий подписке от новых к старым: статус, число попыток, код ответа и последнюю ошибку. Доставки со статусом DEAD исчерпали все попытки
+// @Tags Webhooks
+// @Produce  json
+// @Param organizationId path string true "ID организации"
+// @Param webhookId path string true "ID подписки"
+// @Param status query string false "Статус доставки (PENDING, DELIVERED, DEAD)"
+// @Param limit query int false "Максимальное число доставок (по умолчанию 5, не больше 50)"
+// @Param offset query int false "Сколько доставок пропустить"
+// @Param username query string false "Имя пользователя (если запрос без токена)"
+// @Success 200 {array} models.WebhookDelivery "Список доставок"
+// @Failure 400 {string} string "Неверные параметры запроса"
+// @Failure 401 {string} string "Пользователь не указан"
+// @Failure 403 {string} string "Недостаточно прав в организации"
+// @Failure 404 {string} string "Подписка не найдена"
+// @Failure 500 {string} string "Ошибка при получении истории доставок"
+// @Router /api/organizations/{organizationId}/webhooks/{webhookId}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := parseWebhookPath(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status := models.DeliveryStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		http.Error(w, "status must be one of PENDING, DELIVERED, DEAD", http.StatusBadRequest)
+		return
+	}
+
+	if h.authorizeWebhooks(w, r, orgID) == nil {
+		return
+	}
+
+	_, err = h.WebhookRepo.GetSubscription(r.Context(), orgID, webhookID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		http.Error(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deliveries, err := h.WebhookRepo.GetDeliveries(r.Context(), webhookID, status, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+func (h *WebhookHandler) authorizeWebhooks(w http.ResponseWriter, r *http.Request, orgID uuid.UUID) *models.OrganizationResponsible {
+	username := callerUsername(r, r.URL.Query().Get("username"))
+	return h.Authorizer.authorize(w, r, orgID, username, models.PermissionManageWebhooks)
+}
+
+func parseWebhookPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "invalid organization ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	webhookID, err := uuid.Parse(mux.Vars(r)["webhookId"])
+	if err != nil {
+		http.Error(w, "invalid webhook ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, webhookID, true
+}
+
+func isWebhookURL(value string) bool {
+	if value == "" || len(value) > maxWebhookURLLength {
+		return false
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "failed to generate webhook secret")
+	}
+
+	return hex.EncodeToString(secret), nil
+}
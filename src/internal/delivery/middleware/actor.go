@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/auth"
+)
+
+// AuditActor records who performs the request for the audit log: the authenticated employee
+// or, in compatibility mode, the username query parameter.
+func AuditActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.URL.Query().Get("username")
+		if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+			actor = identity.Username
+		}
+
+		if actor != "" {
+			r = r.WithContext(audit.WithActor(r.Context(), actor))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/auth"
+	"avito_2024/src/internal/domain/interface"
+)
+
+// Authenticate verifies the bearer token and stores the caller identity in the request context.
+// A request with an invalid token, or a token of a deactivated employee or of revoked credentials,
+// is always rejected; a request without a token is rejected only in strict mode and only for paths
+// that are not listed as public.
+func Authenticate(tokens *auth.TokenManager, credentials _interface.AuthRepository, mode auth.Mode, publicPaths ...string) func(http.Handler) http.Handler {
+	public := make(map[string]struct{}, len(publicPaths))
+	for _, path := range publicPaths {
+		public[path] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString, ok := bearerToken(r)
+			if !ok {
+				_, isPublic := public[r.URL.Path]
+				if mode == auth.ModeStrict && !isPublic && r.Method != http.MethodOptions {
+					writeError(w, http.StatusUnauthorized, "authentication required")
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			identity, err := tokens.Verify(tokenString)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+
+			version, err := credentials.GetTokenVersion(r.Context(), identity.UserID)
+			if errors.Cause(err) == sql.ErrNoRows || (err == nil && version != identity.TokenVersion) {
+				writeError(w, http.StatusUnauthorized, "token is revoked")
+				return
+			}
+
+			if err != nil {
+				log.Printf("Authenticate: request_id=%s: %v", GetRequestID(r.Context()), err)
+				writeError(w, http.StatusInternalServerError, "failed to verify token")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", false
+	}
+
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type errorResponse struct {
+	Reason string `json:"reason"`
+}
+
+// writeError responds in the API error format: {"reason": "..."}.
+func writeError(w http.ResponseWriter, status int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Reason: reason})
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/auth"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyFinishTimeout  = 5 * time.Second
+)
+
+// IdempotencyConfig sets how long keys are kept.
+type IdempotencyConfig struct {
+	// TTL is how long a key and its stored response are kept; after that the key can be reused.
+	TTL time.Duration
+	// LockTimeout is how long a request holds a key without a response before a retry may take it over.
+	LockTimeout time.Duration
+}
+
+// responseRecorder passes the response through and keeps a copy of the status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(data []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	rec.body.Write(data)
+	return rec.ResponseWriter.Write(data)
+}
+
+// Idempotency makes a create endpoint safe to retry. The first request with a given
+// Idempotency-Key header is executed and its response stored; retries with the same key
+// and body get the stored response, a retry with a different body gets 422. Keys are scoped
+// to the caller, so a key chosen by another user never replays their response.
+// Responses with a 5xx status are not stored so that the request can be retried.
+func Idempotency(repo _interface.IdempotencyRepository, config IdempotencyConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				writeError(w, http.StatusBadRequest, "idempotency key is too long")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			now := time.Now()
+			lockedUntil := now.Add(config.LockTimeout)
+			reservation := &models.IdempotencyKey{
+				Owner:         idempotencyOwner(r),
+				Key:           key,
+				RequestHash:   hashRequest(r, body),
+				ReservationID: uuid.New(),
+				CreatedAt:     now,
+				LockedUntil:   &lockedUntil,
+				ExpiresAt:     now.Add(config.TTL),
+			}
+
+			existing, reserved, err := repo.ReserveIdempotencyKey(r.Context(), reservation)
+			if err != nil {
+				log.Printf("Idempotency: request_id=%s: %v", GetRequestID(r.Context()), err)
+				writeError(w, http.StatusInternalServerError, "failed to process idempotency key")
+				return
+			}
+
+			if !reserved {
+				replay(w, existing, reservation.RequestHash)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w}
+			completed := false
+			defer func() {
+				finishIdempotencyKey(r, repo, reservation, rec, completed)
+			}()
+
+			next.ServeHTTP(rec, r)
+			completed = true
+		})
+	}
+}
+
+// finishIdempotencyKey stores the response or releases the key. It runs even if the client has gone
+// away or the handler panicked, so it does not use the request context for cancellation.
+func finishIdempotencyKey(r *http.Request, repo _interface.IdempotencyRepository, reservation *models.IdempotencyKey, rec *responseRecorder, completed bool) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), idempotencyFinishTimeout)
+	defer cancel()
+
+	var err error
+	if !completed || rec.status == 0 || rec.status >= http.StatusInternalServerError {
+		err = repo.ReleaseIdempotencyKey(ctx, reservation)
+	} else {
+		err = repo.CompleteIdempotencyKey(ctx, reservation, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+	}
+
+	if err != nil {
+		log.Printf("Idempotency: request_id=%s: %v", GetRequestID(r.Context()), err)
+	}
+}
+
+func replay(w http.ResponseWriter, existing *models.IdempotencyKey, requestHash string) {
+	if existing.RequestHash != requestHash {
+		writeError(w, http.StatusUnprocessableEntity, "idempotency key was already used with a different request")
+		return
+	}
+
+	if existing.ResponseStatus == nil {
+		writeError(w, http.StatusConflict, "request with this idempotency key is still in progress")
+		return
+	}
+
+	if existing.ResponseContentType != nil && *existing.ResponseContentType != "" {
+		w.Header().Set("Content-Type", *existing.ResponseContentType)
+	}
+
+	w.Header().Set(idempotencyReplayedHeader, "true")
+	w.WriteHeader(*existing.ResponseStatus)
+	w.Write(existing.ResponseBody)
+}
+
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyOwner returns the authenticated username or, without a token, the username query parameter.
+func idempotencyOwner(r *http.Request) string {
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		return identity.Username
+	}
+
+	return r.URL.Query().Get("username")
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito_2024/src/internal/auth"
+	"avito_2024/src/internal/ratelimit"
+)
+
+// RateLimitConfig sets token bucket limits for read (GET, HEAD, OPTIONS) and write requests.
+type RateLimitConfig struct {
+	Reads  ratelimit.Limit
+	Writes ratelimit.Limit
+	// TrustProxy makes the client IP come from X-Forwarded-For instead of the connection address.
+	TrustProxy bool
+}
+
+// RateLimit limits requests per client IP and per user, taken from the authenticated identity
+// or the username query parameter. A request takes a token from both buckets only if both have one;
+// a rejected request gets 429 with Retry-After. Store errors let the request through.
+func RateLimit(store ratelimit.Store, config RateLimitConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group, limit := "write", config.Writes
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				group, limit = "read", config.Reads
+			}
+
+			keys := []string{group + ":ip:" + clientIP(r, config.TrustProxy)}
+			username := r.URL.Query().Get("username")
+			if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+				username = identity.Username
+			}
+
+			if username != "" {
+				keys = append(keys, group+":user:"+username)
+			}
+
+			result, err := store.TakeAll(r.Context(), keys, limit)
+			if err != nil {
+				log.Printf("Rate limit: request_id=%s keys=%s: %v", GetRequestID(r.Context()), strings.Join(keys, ","), err)
+			} else if !result.Allowed {
+				retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+				writeError(w, http.StatusTooManyRequests, "too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
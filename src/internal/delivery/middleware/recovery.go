@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"expvar"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+var panicsRecovered = expvar.NewInt("http_panics_recovered_total")
+
+// Recovery catches a panic in the handler chain, logs it with the stack trace
+// and responds with 500 in the API error format instead of dropping the connection.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			panicsRecovered.Add(1)
+			log.Printf("Panic: request_id=%s %s %s: %v\n%s", GetRequestID(r.Context()), r.Method, r.URL.Path, rec, debug.Stack())
+
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
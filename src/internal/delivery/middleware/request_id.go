@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/requestid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID takes the request ID from the X-Request-ID header or generates a new one,
+// stores it in the request context and echoes it back in the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		ctx := requestid.NewContext(r.Context(), requestID)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetRequestID returns the request ID stored by RequestID, or an empty string.
+func GetRequestID(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
@@ -0,0 +1,25 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AttachmentRepository interface {
+	// CreateAttachment records an uploaded file as the next revision of its name, at the current
+	// version of the tender or proposal.
+	CreateAttachment(ctx context.Context, attachment *models.Attachment) error
+
+	// GetAttachments returns the latest revisions of the files of the tender or proposal as of the
+	// given version, or of the current version if it is nil. Deleted files are left out.
+	GetAttachments(ctx context.Context, entityType models.AttachmentEntity, entityID uuid.UUID, version *int) ([]models.Attachment, error)
+
+	// GetAttachment returns any revision of a file of the tender or proposal, including deleted ones.
+	GetAttachment(ctx context.Context, entityType models.AttachmentEntity, entityID uuid.UUID, attachmentID uuid.UUID) (*models.Attachment, error)
+
+	// DeleteAttachment removes the latest revision of a file from the current version of the tender or
+	// proposal. The revision is kept for earlier versions.
+	DeleteAttachment(ctx context.Context, entityType models.AttachmentEntity, entityID uuid.UUID, attachmentID uuid.UUID) (*models.Attachment, error)
+}
@@ -0,0 +1,18 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AuctionRepository interface {
+	// PlaceBid accepts the bid if the auction is running and the price undercuts the best bid,
+	// and sets it as the price of the proposal. It returns models.ErrInvalidState otherwise.
+	PlaceBid(ctx context.Context, bid *models.AuctionBid) error
+
+	// GetLeaderboard returns the stored auction state, which the caller advances to the current time,
+	// and the best bids of the published proposals.
+	GetLeaderboard(ctx context.Context, tenderID uuid.UUID) (*models.Leaderboard, error)
+}
@@ -0,0 +1,11 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuditRepository interface {
+	GetAuditEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error)
+}
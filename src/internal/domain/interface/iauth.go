@@ -0,0 +1,18 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuthRepository interface {
+	GetCredentials(ctx context.Context, username string) (*models.EmployeeCredentials, error)
+
+	SetPassword(ctx context.Context, credentials *models.EmployeeCredentials, passwordHash string) error
+
+	// GetTokenVersion returns sql.ErrNoRows for a deactivated or deleted employee.
+	GetTokenVersion(ctx context.Context, userID uuid.UUID) (int, error)
+}
@@ -0,0 +1,22 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type EmployeeRepository interface {
+	CreateEmployee(ctx context.Context, employee *models.Employee, passwordHash string) error
+
+	GetEmployeeByUsername(ctx context.Context, username string) (*models.Employee, error)
+
+	EditEmployee(ctx context.Context, employee *models.Employee) error
+
+	DeactivateEmployee(ctx context.Context, username string) error
+
+	SetPasswordReset(ctx context.Context, username string, tokenHash string, expiresAt time.Time) error
+
+	CheckUserIsOwnerOf(ctx context.Context, ownerUsername string, username string) (bool, error)
+}
@@ -0,0 +1,22 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type EvaluationRepository interface {
+	GetCriteria(ctx context.Context, tenderID uuid.UUID) ([]models.EvaluationCriterion, error)
+
+	// SetCriteria replaces the criteria of the tender. It returns models.ErrInvalidState if bids
+	// have already been scored.
+	SetCriteria(ctx context.Context, tenderID uuid.UUID, criteria []models.EvaluationCriterion) error
+
+	SetScores(ctx context.Context, proposalID uuid.UUID, authorID uuid.UUID, scores []models.ProposalScore) error
+
+	GetScores(ctx context.Context, proposalID uuid.UUID) ([]models.ProposalScore, error)
+
+	GetRanking(ctx context.Context, tenderID uuid.UUID) ([]models.RankingEntry, error)
+}
@@ -0,0 +1,20 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey stores a new key or takes over an expired one; if the key is held by another
+	// request or completed, it returns the stored record and false.
+	ReserveIdempotencyKey(ctx context.Context, reservation *models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+
+	CompleteIdempotencyKey(ctx context.Context, reservation *models.IdempotencyKey, status int, contentType string, body []byte) error
+
+	ReleaseIdempotencyKey(ctx context.Context, reservation *models.IdempotencyKey) error
+
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time, limit int) (int, error)
+}
@@ -0,0 +1,19 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type InvitationRepository interface {
+	// CreateInvitation invites the organization or employee of the request to the tender. It returns
+	// sql.ErrNoRows if the invitee does not exist and models.ErrAlreadyExists if it is already invited.
+	CreateInvitation(ctx context.Context, tenderID uuid.UUID, request models.InviteRequest, invitedByUsername string) (*models.TenderInvitation, error)
+
+	// GetInvitations returns the invitations to the tender, including revoked ones.
+	GetInvitations(ctx context.Context, tenderID uuid.UUID) ([]models.TenderInvitation, error)
+
+	RevokeInvitation(ctx context.Context, tenderID uuid.UUID, invitationID uuid.UUID) (*models.TenderInvitation, error)
+}
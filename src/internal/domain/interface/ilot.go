@@ -0,0 +1,16 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type LotRepository interface {
+	GetLots(ctx context.Context, tenderID uuid.UUID) ([]models.TenderLot, error)
+
+	// SetLots replaces the lots of the tender. It returns models.ErrInvalidState if bids have already
+	// been submitted to the tender.
+	SetLots(ctx context.Context, tenderID uuid.UUID, lots []models.TenderLot) error
+}
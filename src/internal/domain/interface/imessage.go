@@ -0,0 +1,19 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MessageRepository interface {
+	CreateMessage(ctx context.Context, message *models.ProposalMessage) error
+
+	// GetMessages returns the thread of the proposal, newest first.
+	GetMessages(ctx context.Context, proposalID uuid.UUID, limit, offset int) ([]models.ProposalMessage, error)
+
+	// MarkRead marks the message and the earlier unread messages of the other side of the thread read
+	// by the reader. It returns models.ErrInvalidState if the message was sent by the reader's side.
+	MarkRead(ctx context.Context, proposalID uuid.UUID, messageID uuid.UUID, side models.MessageSide, readerID uuid.UUID) (*models.ProposalMessage, error)
+}
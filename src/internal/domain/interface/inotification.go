@@ -0,0 +1,23 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	// CreateForEvent notifies the employees concerned by the event who have not turned its notifications off.
+	CreateForEvent(ctx context.Context, event models.DomainEvent) (int, error)
+
+	GetNotifications(ctx context.Context, employeeID uuid.UUID, includeRead bool, limit int, offset int) ([]models.Notification, error)
+
+	MarkRead(ctx context.Context, employeeID uuid.UUID, notificationID uuid.UUID) (*models.Notification, error)
+
+	MarkAllRead(ctx context.Context, employeeID uuid.UUID) (int, error)
+
+	GetPreferences(ctx context.Context, employeeID uuid.UUID) ([]models.NotificationPreference, error)
+
+	SetPreferences(ctx context.Context, employeeID uuid.UUID, preferences []models.NotificationPreference) error
+}
@@ -0,0 +1,28 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, organization *models.Organization, responsibleUsername string) error
+
+	GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*models.Organization, error)
+
+	GetOrganizations(ctx context.Context, limit int, offset int) ([]models.Organization, error)
+
+	EditOrganization(ctx context.Context, organization *models.Organization) error
+
+	DeleteOrganization(ctx context.Context, orgID uuid.UUID) error
+
+	GetResponsibles(ctx context.Context, orgID uuid.UUID) ([]models.OrganizationResponsible, error)
+
+	AddResponsible(ctx context.Context, orgID uuid.UUID, username string, role models.ResponsibleRole, grantedByUsername string) (*models.OrganizationResponsible, error)
+
+	ChangeResponsibleRole(ctx context.Context, orgID uuid.UUID, username string, role models.ResponsibleRole) (*models.OrganizationResponsible, error)
+
+	RemoveResponsible(ctx context.Context, orgID uuid.UUID, username string) error
+}
@@ -0,0 +1,22 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OutboxRepository interface {
+	// ProcessPending passes up to limit due events, oldest first, to publish and marks the delivered ones
+	// as published. A failed event is retried at the time returned by retryAt for its number of failed
+	// attempts, or dead-lettered if retryAt returns false; later events of the same tender or proposal
+	// wait for it, so each aggregate keeps its order while the others go on.
+	ProcessPending(ctx context.Context, limit int, publish func(context.Context, models.DomainEvent) error, retryAt func(failedAttempts int) (time.Time, bool)) (int, error)
+
+	GetEvent(ctx context.Context, eventID uuid.UUID) (*models.DomainEvent, error)
+
+	// GetEventAudience reports whether the event is public and, if it is not, which employees may see it.
+	GetEventAudience(ctx context.Context, event models.DomainEvent) (bool, []uuid.UUID, error)
+}
@@ -1,32 +1,42 @@
 package _interface
 
 import (
+	"context"
+
 	"avito_2024/src/internal/domain/models"
 	"github.com/google/uuid"
 )
 
 type ProposalRepository interface {
-	CheckUserBelongsToOrganizationByID(orgID uuid.UUID, userID uuid.UUID) (bool, error)
+	CreateProposal(ctx context.Context, proposal *models.Proposal) error
+
+	PublishProposal(ctx context.Context, proposalID uuid.UUID) error
+
+	CancelProposal(ctx context.Context, proposalID uuid.UUID) error
+
+	EditProposal(ctx context.Context, proposal *models.Proposal) error
 
-	CreateProposal(proposal *models.Proposal) error
+	// AcknowledgeAmendment records that the author accepts the current version of the tender without
+	// revising the proposal, so the proposal is no longer stale.
+	AcknowledgeAmendment(ctx context.Context, proposalID uuid.UUID) (*models.Proposal, error)
 
-	PublishProposal(proposalID uuid.UUID) error
+	AgreeProposal(ctx context.Context, proposalID uuid.UUID) error
 
-	CancelProposal(proposalID uuid.UUID) error
+	DeclineProposal(ctx context.Context, proposalID uuid.UUID) error
 
-	EditProposal(proposal *models.Proposal) error
+	SubmitDecision(ctx context.Context, proposalID uuid.UUID, authorID uuid.UUID, decision models.Decision) (*models.Proposal, error)
 
-	AgreeProposal(proposalID uuid.UUID) error
+	AddFeedback(ctx context.Context, feedback *models.ProposalFeedback) error
 
-	DeclineProposal(proposalID uuid.UUID) error
+	GetProposalByID(ctx context.Context, proposalID uuid.UUID) (*models.Proposal, error)
 
-	GetProposalByID(proposalID uuid.UUID) (*models.Proposal, error)
+	GetProposalsByTender(ctx context.Context, tenderID uuid.UUID, sort models.ProposalSort) ([]models.Proposal, error)
 
-	GetProposalsByTender(tenderID uuid.UUID) ([]models.Proposal, error)
+	GetSealedProposals(ctx context.Context, tenderID uuid.UUID) ([]models.Proposal, error)
 
-	GetProposalsByUsername(username string) ([]models.Proposal, error)
+	GetProposalsByUsername(ctx context.Context, username string) ([]models.Proposal, error)
 
-	RollbackProposal(bidID uuid.UUID, version int) (*models.Proposal, error)
+	RollbackProposal(ctx context.Context, bidID uuid.UUID, version int) (*models.Proposal, error)
 
-	GetProposalStatus(proposalID uuid.UUID) (string, error)
+	GetProposalStatus(ctx context.Context, proposalID uuid.UUID) (string, error)
 }
@@ -0,0 +1,21 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type QuestionRepository interface {
+	CreateQuestion(ctx context.Context, question *models.TenderQuestion) error
+
+	// GetQuestions returns the answered questions of the tender, oldest first. Unanswered questions
+	// are only returned to the responsibles of the tender organization and of the asking organization.
+	GetQuestions(ctx context.Context, tenderID uuid.UUID, username string, limit, offset int) ([]models.TenderQuestion, error)
+
+	// AnswerQuestion answers the question; an answer with an amendment note also creates a new version
+	// of the tender. It returns models.ErrInvalidState if the question is already answered or the
+	// tender is not published.
+	AnswerQuestion(ctx context.Context, tenderID uuid.UUID, questionID uuid.UUID, request models.AnswerQuestionRequest, answeredBy uuid.UUID) (*models.TenderQuestion, error)
+}
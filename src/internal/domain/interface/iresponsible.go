@@ -0,0 +1,16 @@
+package _interface
+
+import (
+	"context"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// ResponsibleRepository looks up the caller's responsible record, including the role,
+// for authorization checks.
+type ResponsibleRepository interface {
+	GetResponsible(ctx context.Context, orgID uuid.UUID, username string) (*models.OrganizationResponsible, error)
+
+	GetResponsibleByUserID(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*models.OrganizationResponsible, error)
+}
@@ -1,28 +1,43 @@
 package _interface
 
 import (
+	"context"
+	"time"
+
 	"avito_2024/src/internal/domain/models"
 	"github.com/google/uuid"
 )
 
 type TenderService interface {
-	CheckUserBelongsToOrganization(orgID uuid.UUID, username string) (bool, error)
+	CreateTender(ctx context.Context, tender *models.Tender) (*models.Tender, error)
+
+	PublishTender(ctx context.Context, tenderID uuid.UUID) error
+
+	CloseTender(ctx context.Context, tenderID uuid.UUID) error
+
+	EditTender(ctx context.Context, updatedTender *models.Tender) error
 
-	CreateTender(tender *models.Tender) (*models.Tender, error)
+	GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*models.Tender, error)
 
-	PublishTender(tenderID uuid.UUID) error
+	// GetTenders returns the published tenders visible to the user.
+	GetTenders(ctx context.Context, serviceType string, username string) ([]models.Tender, error)
 
-	CloseTender(tenderID uuid.UUID) error
+	// CanViewTender reports whether the user may see the tender: public tenders are visible to everyone,
+	// private ones to the responsibles of the tender organization and to invitees.
+	CanViewTender(ctx context.Context, tenderID uuid.UUID, username string) (bool, error)
 
-	EditTender(updatedTender *models.Tender) error
+	GetMyTenders(ctx context.Context, username string) ([]models.Tender, error)
 
-	GetTenderByID(tenderID uuid.UUID) (*models.Tender, error)
+	RollbackTender(ctx context.Context, tenderID uuid.UUID, version int) (*models.Tender, error)
 
-	GetTenders(serviceType string) ([]models.Tender, error)
+	GetTenderStatus(ctx context.Context, tenderID uuid.UUID) (string, error)
 
-	GetMyTenders(username string) ([]models.Tender, error)
+	CloseExpiredTenders(ctx context.Context, now time.Time, limit int) (int, error)
 
-	RollbackTender(tenderID uuid.UUID, version int) (*models.Tender, error)
+	// GetTendersToOpen returns sealed tenders whose submission deadline has passed and bids are not opened.
+	GetTendersToOpen(ctx context.Context, now time.Time, limit int) ([]models.Tender, error)
 
-	GetTenderStatus(tenderID uuid.UUID) (string, error)
+	// OpenBids stores the decrypted proposals in the clear and marks the bids of the sealed tender opened.
+	// opened must hold every sealed proposal of the tender.
+	OpenBids(ctx context.Context, tenderID uuid.UUID, opened []models.Proposal) error
 }
@@ -0,0 +1,30 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"avito_2024/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription, createdByUsername string) error
+
+	GetSubscriptions(ctx context.Context, orgID uuid.UUID) ([]models.WebhookSubscription, error)
+
+	GetSubscription(ctx context.Context, orgID uuid.UUID, subscriptionID uuid.UUID) (*models.WebhookSubscription, error)
+
+	DeleteSubscription(ctx context.Context, orgID uuid.UUID, subscriptionID uuid.UUID) error
+
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, status models.DeliveryStatus, limit int, offset int) ([]models.WebhookDelivery, error)
+
+	// EnqueueDeliveries creates a pending delivery of the event for every subscription interested in it.
+	EnqueueDeliveries(ctx context.Context, event models.DomainEvent) (int, error)
+
+	// ClaimDueDeliveries returns pending deliveries whose attempt is due and postpones them by lease,
+	// so that other workers skip them while they are being delivered.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.DueDelivery, error)
+
+	RecordAttempt(ctx context.Context, deliveryID uuid.UUID, status models.DeliveryStatus, attempt models.DeliveryAttempt, nextAttemptAt *time.Time) error
+}
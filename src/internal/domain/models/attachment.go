@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentEntity string
+
+const (
+	AttachmentTender   AttachmentEntity = "tender"
+	AttachmentProposal AttachmentEntity = "proposal"
+)
+
+// Attachment is a revision of a file attached to a tender or a proposal. Uploading a file with the
+// name of an existing one adds a revision; the entity version at upload ties the revision to the
+// version of the tender or proposal it belongs to.
+type Attachment struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	EntityType     AttachmentEntity `db:"entity_type" json:"entity_type"`
+	EntityID       uuid.UUID        `db:"entity_id" json:"entity_id"`
+	TenderID       uuid.UUID        `db:"tender_id" json:"tender_id"`
+	FileName       string           `db:"file_name" json:"file_name"`
+	ContentType    string           `db:"content_type" json:"content_type"`
+	Size           int64            `db:"size" json:"size"`
+	Checksum       string           `db:"checksum_sha256" json:"checksum_sha256"`
+	StorageKey     string           `db:"storage_key" json:"-"`
+	Sealed         bool             `db:"sealed" json:"-"`
+	Version        int              `db:"version" json:"version"`
+	Revision       int              `db:"revision" json:"revision"`
+	UploadedBy     *uuid.UUID       `db:"uploaded_by" json:"uploaded_by"`
+	CreatedAt      time.Time        `db:"created_at" json:"created_at"`
+	DeletedVersion *int             `db:"deleted_version" json:"deleted_version,omitempty"`
+	DeletedAt      *time.Time       `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+// BlobKey returns the storage key of the attachment contents.
+func (a *Attachment) BlobKey() string {
+	return fmt.Sprintf("%ss/%s/%s", a.EntityType, a.EntityID, a.ID)
+}
+
+// AttachmentType is an accepted kind of file. Sniffed is the prefix of the type detected from the
+// file contents, if the format can be recognized by its contents.
+type AttachmentType struct {
+	ContentType string
+	Sniffed     string
+}
+
+// AttachmentTypes are the accepted kinds of files by extension: documents, spreadsheets, images,
+// drawings and archives.
+var AttachmentTypes = map[string]AttachmentType{
+	".pdf":  {ContentType: "application/pdf", Sniffed: "application/pdf"},
+	".doc":  {ContentType: "application/msword"},
+	".docx": {ContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", Sniffed: "application/zip"},
+	".xls":  {ContentType: "application/vnd.ms-excel"},
+	".xlsx": {ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Sniffed: "application/zip"},
+	".odt":  {ContentType: "application/vnd.oasis.opendocument.text", Sniffed: "application/zip"},
+	".ods":  {ContentType: "application/vnd.oasis.opendocument.spreadsheet", Sniffed: "application/zip"},
+	".txt":  {ContentType: "text/plain; charset=utf-8", Sniffed: "text/plain"},
+	".csv":  {ContentType: "text/csv; charset=utf-8", Sniffed: "text/plain"},
+	".png":  {ContentType: "image/png", Sniffed: "image/png"},
+	".jpg":  {ContentType: "image/jpeg", Sniffed: "image/jpeg"},
+	".jpeg": {ContentType: "image/jpeg", Sniffed: "image/jpeg"},
+	".dwg":  {ContentType: "image/vnd.dwg"},
+	".dxf":  {ContentType: "image/vnd.dxf"},
+	".zip":  {ContentType: "application/zip", Sniffed: "application/zip"},
+}
+
+// AttachmentTypeOf returns the accepted type of the file by its name and the type detected from its
+// contents, or false if the file is not accepted.
+func AttachmentTypeOf(fileName string, sniffed string) (AttachmentType, bool) {
+	attachmentType, ok := AttachmentTypes[strings.ToLower(path.Ext(fileName))]
+	if !ok || !strings.HasPrefix(sniffed, attachmentType.Sniffed) {
+		return AttachmentType{}, false
+	}
+
+	return attachmentType, true
+}
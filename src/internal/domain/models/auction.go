@@ -0,0 +1,157 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type TenderKind string
+
+const (
+	TenderStandard TenderKind = "STANDARD"
+	TenderAuction  TenderKind = "AUCTION"
+)
+
+func (k TenderKind) IsValid() bool {
+	return k == TenderStandard || k == TenderAuction
+}
+
+// ValidateAuction checks that auction tenders have complete auction settings and other tenders have none.
+// Auction rounds replace the deadlines, and the bid prices are public, so auctions cannot be sealed.
+func (t *Tender) ValidateAuction(now time.Time) error {
+	if !t.Kind.IsValid() {
+		return errors.New("kind must be STANDARD or AUCTION")
+	}
+
+	if t.Kind != TenderAuction {
+		if t.MinDecrement != nil || t.RoundDuration != nil || t.SnipingExtension != nil || t.AuctionStartsAt != nil {
+			return errors.New("auction settings are only allowed for AUCTION tenders")
+		}
+
+		return nil
+	}
+
+	if t.Sealed {
+		return errors.New("auction tenders cannot be sealed")
+	}
+
+	if t.SubmissionDeadline != nil || t.DecisionDeadline != nil {
+		return errors.New("auction tenders have no deadlines")
+	}
+
+	if t.BudgetMax == nil {
+		return errors.New("budgetMax is required as the auction starting price")
+	}
+
+	if t.MinDecrement == nil || *t.MinDecrement <= 0 {
+		return errors.New("minDecrement must be positive")
+	}
+
+	if t.RoundDuration == nil || *t.RoundDuration <= 0 {
+		return errors.New("roundDuration must be positive")
+	}
+
+	if t.SnipingExtension == nil || *t.SnipingExtension < 0 || *t.SnipingExtension > *t.RoundDuration {
+		return errors.New("snipingExtension must be from 0 to roundDuration")
+	}
+
+	if t.AuctionStartsAt == nil || !t.AuctionStartsAt.After(now) {
+		return errors.New("auctionStartsAt must be in the future")
+	}
+
+	return nil
+}
+
+// Auction is the state of a reverse auction. A round with bids is followed by the next one, and the
+// auction ends with the first round without bids. A bid in the last SnipingExtension seconds of a
+// round extends the round so that the others can respond.
+type Auction struct {
+	TenderID    uuid.UUID `db:"tender_id" json:"tender_id"`
+	Round       int       `db:"round" json:"round"`
+	RoundEndsAt time.Time `db:"round_ends_at" json:"round_ends_at"`
+	RoundBids   int       `db:"round_bids" json:"round_bids"`
+	BestPrice   *Amount   `db:"best_price" json:"best_price,omitempty" swaggertype:"string"`
+	// LastSequence is the sequence number of the last accepted bid.
+	LastSequence int64 `db:"last_sequence" json:"last_sequence"`
+	Ended        bool  `db:"-" json:"ended"`
+}
+
+// Advance brings the stored state to the given time: rounds that ended with bids are followed by new
+// ones, and the auction ends with a round without bids.
+func (a *Auction) Advance(now time.Time, tender *Tender) {
+	roundDuration := time.Duration(*tender.RoundDuration) * time.Second
+	for !a.Ended && !now.Before(a.RoundEndsAt) {
+		if a.RoundBids == 0 {
+			a.Ended = true
+			return
+		}
+
+		a.Round++
+		a.RoundBids = 0
+		a.RoundEndsAt = a.RoundEndsAt.Add(roundDuration)
+	}
+}
+
+// CheckBid checks that the price undercuts the best bid by the minimum decrement or, before the
+// first bid, does not exceed the starting price.
+func (a *Auction) CheckBid(price Amount, tender *Tender) error {
+	if a.BestPrice == nil {
+		if price > *tender.BudgetMax {
+			return errors.Errorf("price must not exceed the starting price %s", tender.BudgetMax)
+		}
+
+		return nil
+	}
+
+	if limit := *a.BestPrice - *tender.MinDecrement; price > limit {
+		return errors.Errorf("price must not exceed %s", limit)
+	}
+
+	return nil
+}
+
+// Accept applies the bid to the auction state, extending the round if the bid came in its last
+// SnipingExtension seconds.
+func (a *Auction) Accept(bid *AuctionBid, tender *Tender) {
+	extension := time.Duration(*tender.SnipingExtension) * time.Second
+	if end := bid.CreatedAt.Add(extension); a.RoundEndsAt.Before(end) {
+		a.RoundEndsAt = end
+	}
+
+	a.LastSequence++
+	a.RoundBids++
+	a.BestPrice = &bid.Price
+
+	bid.Sequence = a.LastSequence
+	bid.Round = a.Round
+}
+
+// AuctionBid is a price offered for a proposal in a reverse auction.
+type AuctionBid struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	TenderID   uuid.UUID `db:"tender_id" json:"tender_id"`
+	Sequence   int64     `db:"sequence" json:"sequence"`
+	ProposalID uuid.UUID `db:"proposal_id" json:"proposal_id"`
+	Round      int       `db:"round" json:"round"`
+	Price      Amount    `db:"price" json:"price" swaggertype:"string" example:"2400.00"`
+	Currency   Currency  `db:"currency" json:"currency" swaggertype:"string" example:"RUB"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// LeaderboardEntry is the best auction bid of a proposal.
+type LeaderboardEntry struct {
+	Rank       int       `db:"-" json:"rank"`
+	ProposalID uuid.UUID `db:"proposal_id" json:"proposal_id"`
+	Price      Amount    `db:"price" json:"price" swaggertype:"string"`
+	Currency   Currency  `db:"currency" json:"currency" swaggertype:"string"`
+	Bids       int       `db:"bids" json:"bids"`
+	BidAt      time.Time `db:"bid_at" json:"bid_at"`
+}
+
+// Leaderboard is the auction state with the proposals ordered by their best bids.
+type Leaderboard struct {
+	Auction
+	Entries []LeaderboardEntry `json:"entries"`
+}
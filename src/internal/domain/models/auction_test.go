@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+var auctionStart = time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+
+func testAuctionTender() *Tender {
+	budgetMax := Amount(500000)
+	minDecrement := Amount(10000)
+	roundDuration := 300
+	snipingExtension := 60
+
+	return &Tender{
+		Kind:             TenderAuction,
+		BudgetMax:        &budgetMax,
+		MinDecrement:     &minDecrement,
+		RoundDuration:    &roundDuration,
+		SnipingExtension: &snipingExtension,
+		AuctionStartsAt:  &auctionStart,
+	}
+}
+
+func TestAuctionCheckBid(t *testing.T) {
+	tender := testAuctionTender()
+	auction := Auction{Round: 1, RoundEndsAt: auctionStart.Add(5 * time.Minute)}
+
+	if err := auction.CheckBid(500001, tender); err == nil {
+		t.Error("CheckBid() above the starting price succeeded, want an error")
+	}
+
+	if err := auction.CheckBid(500000, tender); err != nil {
+		t.Errorf("CheckBid() at the starting price error = %v", err)
+	}
+
+	best := Amount(400000)
+	auction.BestPrice = &best
+
+	if err := auction.CheckBid(390001, tender); err == nil {
+		t.Error("CheckBid() undercutting by less than minDecrement succeeded, want an error")
+	}
+
+	if err := auction.CheckBid(390000, tender); err != nil {
+		t.Errorf("CheckBid() undercutting by minDecrement error = %v", err)
+	}
+}
+
+func TestAuctionAcceptOrdersBids(t *testing.T) {
+	tender := testAuctionTender()
+	roundEnd := auctionStart.Add(5 * time.Minute)
+	auction := Auction{Round: 1, RoundEndsAt: roundEnd}
+
+	first := AuctionBid{Price: 490000, CreatedAt: auctionStart.Add(time.Minute)}
+	auction.Accept(&first, tender)
+
+	second := AuctionBid{Price: 480000, CreatedAt: auctionStart.Add(2 * time.Minute)}
+	auction.Accept(&second, tender)
+
+	if first.Sequence != 1 || second.Sequence != 2 || auction.LastSequence != 2 {
+		t.Errorf("sequences = %d, %d, last %d, want 1, 2, last 2", first.Sequence, second.Sequence, auction.LastSequence)
+	}
+
+	if first.Round != 1 || second.Round != 1 || auction.RoundBids != 2 {
+		t.Errorf("rounds = %d, %d with %d bids, want round 1 with 2 bids", first.Round, second.Round, auction.RoundBids)
+	}
+
+	if auction.BestPrice == nil || *auction.BestPrice != 480000 {
+		t.Errorf("best price = %v, want 4800.00", auction.BestPrice)
+	}
+
+	if !auction.RoundEndsAt.Equal(roundEnd) {
+		t.Errorf("round ends at %v, want %v: bids before the last minute must not extend it", auction.RoundEndsAt, roundEnd)
+	}
+}
+
+func TestAuctionAcceptExtendsRoundOnSniping(t *testing.T) {
+	tender := testAuctionTender()
+	roundEnd := auctionStart.Add(5 * time.Minute)
+	auction := Auction{Round: 1, RoundEndsAt: roundEnd}
+
+	bidAt := roundEnd.Add(-20 * time.Second)
+	auction.Accept(&AuctionBid{Price: 490000, CreatedAt: bidAt}, tender)
+
+	if want := bidAt.Add(time.Minute); !auction.RoundEndsAt.Equal(want) {
+		t.Errorf("round ends at %v, want %v", auction.RoundEndsAt, want)
+	}
+}
+
+func TestAuctionAdvance(t *testing.T) {
+	tender := testAuctionTender()
+	roundEnd := auctionStart.Add(5 * time.Minute)
+
+	auction := Auction{Round: 1, RoundEndsAt: roundEnd, RoundBids: 1}
+	auction.Advance(roundEnd.Add(-time.Second), tender)
+	if auction.Round != 1 || auction.Ended {
+		t.Fatalf("before the round end: round %d, ended %v, want round 1 running", auction.Round, auction.Ended)
+	}
+
+	auction.Advance(roundEnd, tender)
+	if auction.Round != 2 || auction.RoundBids != 0 || auction.Ended || !auction.RoundEndsAt.Equal(roundEnd.Add(5*time.Minute)) {
+		t.Fatalf("after a round with bids: %+v, want round 2 running until %v", auction, roundEnd.Add(5*time.Minute))
+	}
+
+	auction.Advance(roundEnd.Add(time.Hour), tender)
+	if !auction.Ended || auction.Round != 2 {
+		t.Errorf("after a round without bids: round %d, ended %v, want round 2 ended", auction.Round, auction.Ended)
+	}
+}
+
+func TestAuctionAdvanceEndsWithoutBids(t *testing.T) {
+	roundEnd := auctionStart.Add(5 * time.Minute)
+	auction := Auction{Round: 1, RoundEndsAt: roundEnd}
+
+	auction.Advance(roundEnd, testAuctionTender())
+	if !auction.Ended || auction.Round != 1 {
+		t.Errorf("round %d, ended %v, want round 1 ended", auction.Round, auction.Ended)
+	}
+}
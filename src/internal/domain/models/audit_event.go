@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// RawJSON is a JSON document stored as is in a JSONB column.
+type RawJSON []byte
+
+func (j *RawJSON) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*j = nil
+	case string:
+		*j = RawJSON(value)
+	case []byte:
+		*j = append(RawJSON(nil), value...)
+	default:
+		return errors.Errorf("cannot scan %T into RawJSON", src)
+	}
+
+	return nil
+}
+
+func (j RawJSON) MarshalJSON() ([]byte, error) {
+	if j == nil {
+		return []byte("null"), nil
+	}
+
+	return j, nil
+}
+
+type AuditEvent struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	Actor          *string    `db:"actor" json:"actor"`
+	Action         string     `db:"action" json:"action"`
+	EntityType     string     `db:"entity_type" json:"entity_type"`
+	EntityID       uuid.UUID  `db:"entity_id" json:"entity_id"`
+	OrganizationID *uuid.UUID `db:"organization_id" json:"organization_id"`
+	Before         RawJSON    `db:"before" json:"before" swaggertype:"object"`
+	After          RawJSON    `db:"after" json:"after" swaggertype:"object"`
+	RequestID      *string    `db:"request_id" json:"request_id"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+}
+
+type AuditFilter struct {
+	Username string
+	EntityID *uuid.UUID
+	Actor    string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	Offset   int
+}
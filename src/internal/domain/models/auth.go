@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmployeeCredentials struct {
+	ID                     uuid.UUID  `db:"id"`
+	Username               string     `db:"username"`
+	PasswordHash           *string    `db:"password_hash"`
+	PasswordResetHash      *string    `db:"password_reset_hash"`
+	PasswordResetExpiresAt *time.Time `db:"password_reset_expires_at"`
+	TokenVersion           int        `db:"token_version"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// SetPasswordRequest changes the password of an employee. The request is authorized by either the
+// current password or a reset token issued by an owner of the employee's organization.
+type SetPasswordRequest struct {
+	Username        string `json:"username" binding:"required"`
+	CurrentPassword string `json:"current_password"`
+	ResetToken      string `json:"reset_token"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type PasswordResetResponse struct {
+	ResetToken string    `json:"reset_token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTenderPublished  EventType = "tender.published"
+	EventTenderClosed     EventType = "tender.closed"
+	EventBidsOpened       EventType = "tender.bids_opened"
+	EventBidCreated       EventType = "bid.created"
+	EventBidPublished     EventType = "bid.published"
+	EventBidCanceled      EventType = "bid.canceled"
+	EventBidApproved      EventType = "bid.approved"
+	EventBidRejected      EventType = "bid.rejected"
+	EventBidStale         EventType = "bid.stale"
+	EventFeedbackAdded    EventType = "bid.feedback_added"
+	EventMessageSent      EventType = "bid.message_sent"
+	EventAuctionBid       EventType = "tender.auction_bid"
+	EventLotAwarded       EventType = "tender.lot_awarded"
+	EventQuestionAnswered EventType = "tender.question_answered"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventTenderPublished, EventTenderClosed, EventBidsOpened, EventBidCreated, EventBidPublished, EventBidCanceled,
+		EventBidApproved, EventBidRejected, EventBidStale, EventFeedbackAdded, EventMessageSent, EventAuctionBid, EventLotAwarded, EventQuestionAnswered:
+		return true
+	}
+
+	return false
+}
+
+// DomainEvent is a change in the tender and proposal flows published to downstream systems.
+// Payload holds the state of the aggregate after the change.
+type DomainEvent struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	Type           EventType  `db:"event_type" json:"type"`
+	AggregateType  string     `db:"aggregate_type" json:"aggregate_type"`
+	AggregateID    uuid.UUID  `db:"aggregate_id" json:"aggregate_id"`
+	OrganizationID *uuid.UUID `db:"organization_id" json:"organization_id"`
+	Payload        RawJSON    `db:"payload" json:"payload" swaggertype:"object"`
+	RequestID      *string    `db:"request_id" json:"request_id"`
+	CreatedAt      time.Time  `db:"created_at" json:"occurred_at"`
+}
@@ -7,10 +7,18 @@ import (
 )
 
 type Employee struct {
-	ID        uuid.UUID `db:"id" json:"id" binding:"required"`
-	Username  string    `db:"username" json:"username" binding:"required"`
-	FirstName string    `db:"first_name" json:"first_name"`
-	LastName  string    `db:"last_name" json:"last_name"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	ID            uuid.UUID  `db:"id" json:"id" binding:"required"`
+	Username      string     `db:"username" json:"username" binding:"required"`
+	FirstName     string     `db:"first_name" json:"first_name"`
+	LastName      string     `db:"last_name" json:"last_name"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+	DeactivatedAt *time.Time `db:"deactivated_at" json:"deactivated_at,omitempty"`
+}
+
+type RegisterEmployeeRequest struct {
+	Username  string `json:"username" binding:"required"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Password  string `json:"password" binding:"required"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrAlreadyExists is returned by repositories when a unique constraint is violated.
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrLastResponsible is returned when removing the only responsible of an organization.
+var ErrLastResponsible = errors.New("organization must keep at least one responsible")
+
+// ErrLastOwner is returned when an organization would be left without an owner.
+var ErrLastOwner = errors.New("organization must keep at least one owner")
+
+// ErrInvalidState is returned when an operation is not allowed in the current status.
+var ErrInvalidState = errors.New("operation is not allowed in the current status")
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CriterionKind string
+
+const (
+	CriterionPrice        CriterionKind = "price"
+	CriterionDeliveryTime CriterionKind = "delivery_time"
+	CriterionExperience   CriterionKind = "experience"
+	CriterionCustom       CriterionKind = "custom"
+)
+
+func (k CriterionKind) IsValid() bool {
+	switch k {
+	case CriterionPrice, CriterionDeliveryTime, CriterionExperience, CriterionCustom:
+		return true
+	}
+
+	return false
+}
+
+const (
+	MinScore = 0
+	MaxScore = 10
+)
+
+// EvaluationCriterion is a weighted criterion the bids on a tender are scored by.
+type EvaluationCriterion struct {
+	ID       uuid.UUID     `db:"id" json:"id"`
+	TenderID uuid.UUID     `db:"tender_id" json:"tender_id"`
+	Name     string        `db:"name" json:"name"`
+	Kind     CriterionKind `db:"kind" json:"kind"`
+	// Weight is the relative importance of the criterion; weights of a tender need not sum to 100.
+	Weight    int       `db:"weight" json:"weight"`
+	Position  int       `db:"position" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ProposalScore is the score a responsible gave a proposal by one criterion.
+type ProposalScore struct {
+	ProposalID  uuid.UUID `db:"proposal_id" json:"proposal_id"`
+	CriterionID uuid.UUID `db:"criterion_id" json:"criterion_id"`
+	AuthorID    uuid.UUID `db:"author_id" json:"author_id"`
+	Score       int       `db:"score" json:"score"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// RankingEntry is a published proposal with its aggregated score. Score is the weighted mean of
+// the average scores by each criterion; criteria nobody has scored yet count as zero.
+type RankingEntry struct {
+	Rank           int       `db:"-" json:"rank"`
+	ProposalID     uuid.UUID `db:"proposal_id" json:"proposal_id"`
+	Title          string    `db:"title" json:"title"`
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	Price          *Amount   `db:"price" json:"price,omitempty" swaggertype:"string"`
+	Currency       Currency  `db:"currency" json:"currency,omitempty" swaggertype:"string"`
+	Score          float64   `db:"score" json:"score"`
+	// ScoredCriteria is the number of criteria the proposal has at least one score by.
+	ScoredCriteria int `db:"scored_criteria" json:"scored_criteria"`
+	Scorers        int `db:"scorers" json:"scorers"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyKey struct {
+	Owner               string     `db:"owner" json:"owner"`
+	Key                 string     `db:"key" json:"key"`
+	RequestHash         string     `db:"request_hash" json:"request_hash"`
+	ReservationID       uuid.UUID  `db:"reservation_id" json:"reservation_id"`
+	ResponseStatus      *int       `db:"response_status" json:"response_status"`
+	ResponseContentType *string    `db:"response_content_type" json:"response_content_type"`
+	ResponseBody        []byte     `db:"response_body" json:"response_body"`
+	CreatedAt           time.Time  `db:"created_at" json:"created_at"`
+	CompletedAt         *time.Time `db:"completed_at" json:"completed_at"`
+	LockedUntil         *time.Time `db:"locked_until" json:"locked_until"`
+	ExpiresAt           time.Time  `db:"expires_at" json:"expires_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TenderVisibility string
+
+const (
+	VisibilityPublic  TenderVisibility = "PUBLIC"
+	VisibilityPrivate TenderVisibility = "PRIVATE"
+)
+
+func (v TenderVisibility) IsValid() bool {
+	return v == VisibilityPublic || v == VisibilityPrivate
+}
+
+// TenderInvitation lets an organization's responsibles or a single employee see and bid on a private tender.
+type TenderInvitation struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	TenderID       uuid.UUID  `db:"tender_id" json:"tender_id"`
+	OrganizationID *uuid.UUID `db:"organization_id" json:"organization_id,omitempty"`
+	EmployeeID     *uuid.UUID `db:"employee_id" json:"employee_id,omitempty"`
+	Username       *string    `db:"username" json:"username,omitempty"`
+	InvitedBy      *uuid.UUID `db:"invited_by" json:"invited_by"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	RevokedAt      *time.Time `db:"revoked_at" json:"revoked_at,omitempty"`
+}
+
+// InviteRequest names either an organization or an employee to invite.
+type InviteRequest struct {
+	OrganizationID *uuid.UUID `json:"organization_id"`
+	Username       string     `json:"username"`
+}
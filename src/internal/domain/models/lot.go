@@ -0,0 +1,109 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type LotStatus string
+
+const (
+	LotOpen    LotStatus = "OPEN"
+	LotAwarded LotStatus = "AWARDED"
+)
+
+// TenderLot is a separable part of a tender. Its budget is in the tender currency.
+type TenderLot struct {
+	ID                uuid.UUID  `db:"id" json:"id"`
+	TenderID          uuid.UUID  `db:"tender_id" json:"tender_id"`
+	Title             string     `db:"title" json:"title"`
+	Description       string     `db:"description" json:"description"`
+	ServiceType       string     `db:"service_type" json:"service_type"`
+	BudgetMin         *Amount    `db:"budget_min" json:"budget_min,omitempty" swaggertype:"string" example:"1000.00"`
+	BudgetMax         *Amount    `db:"budget_max" json:"budget_max,omitempty" swaggertype:"string" example:"5000.00"`
+	Position          int        `db:"position" json:"-"`
+	Status            LotStatus  `db:"status" json:"status"`
+	AwardedProposalID *uuid.UUID `db:"awarded_proposal_id" json:"awarded_proposal_id,omitempty"`
+	AwardedAt         *time.Time `db:"awarded_at" json:"awarded_at,omitempty"`
+	CreatedAt         time.Time  `db:"created_at" json:"created_at"`
+}
+
+// ForLots returns a copy of the tender with the budget of a bid on the given lots: the sums of the
+// lot budgets, where a bound applies only if every lot has it.
+func (t *Tender) ForLots(lots []TenderLot) *Tender {
+	bid := *t
+	bid.BudgetMin, bid.BudgetMax = nil, nil
+
+	var budgetMin, budgetMax Amount
+	hasMin, hasMax := len(lots) > 0, len(lots) > 0
+	for _, lot := range lots {
+		if lot.BudgetMin != nil {
+			budgetMin += *lot.BudgetMin
+		} else {
+			hasMin = false
+		}
+
+		if lot.BudgetMax != nil {
+			budgetMax += *lot.BudgetMax
+		} else {
+			hasMax = false
+		}
+	}
+
+	if hasMin {
+		bid.BudgetMin = &budgetMin
+	}
+	if hasMax {
+		bid.BudgetMax = &budgetMax
+	}
+
+	return &bid
+}
+
+// LotIDs is a list of lot IDs stored as a comma-separated string. A nil list is stored as NULL.
+type LotIDs []uuid.UUID
+
+func (l *LotIDs) Scan(src interface{}) error {
+	var value string
+	switch src := src.(type) {
+	case nil:
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return errors.Errorf("cannot scan %T into LotIDs", src)
+	}
+
+	*l = LotIDs{}
+	for _, id := range strings.Split(value, ",") {
+		if id == "" {
+			continue
+		}
+
+		lotID, err := uuid.Parse(id)
+		if err != nil {
+			return errors.Wrap(err, "invalid lot ID")
+		}
+		*l = append(*l, lotID)
+	}
+
+	return nil
+}
+
+func (l LotIDs) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	values := make([]string, len(l))
+	for i, id := range l {
+		values[i] = id.String()
+	}
+
+	return strings.Join(values, ","), nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageSide is the party of a proposal thread: the tender organization or the bidding organization.
+type MessageSide string
+
+const (
+	SideTender MessageSide = "TENDER"
+	SideBidder MessageSide = "BIDDER"
+)
+
+// ProposalMessage is a message in the private thread of a proposal between the tender organization and
+// the proposal organization. ReadAt and ReadBy are set when a responsible of the other side reads it.
+type ProposalMessage struct {
+	ID             uuid.UUID   `db:"id" json:"id"`
+	ProposalID     uuid.UUID   `db:"proposal_id" json:"proposal_id"`
+	OrganizationID uuid.UUID   `db:"organization_id" json:"organization_id"`
+	SenderSide     MessageSide `db:"sender_side" json:"sender_side"`
+	AuthorID       *uuid.UUID  `db:"author_id" json:"author_id"`
+	Body           string      `db:"body" json:"body"`
+	CreatedAt      time.Time   `db:"created_at" json:"created_at"`
+	ReadAt         *time.Time  `db:"read_at" json:"read_at"`
+	ReadBy         *uuid.UUID  `db:"read_by" json:"read_by,omitempty"`
+}
+
+type SendMessageRequest struct {
+	Body string `json:"body" binding:"required"`
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// amountScale is the number of minor units in a major unit of every currency.
+const amountScale = 100
+
+// maxAmountDigits is the number of integer digits that fit into the NUMERIC(15, 2) columns.
+const maxAmountDigits = 13
+
+// Amount is a non-negative sum of money in minor units. It is exchanged as a decimal string,
+// e.g. "1500.50", and stored as NUMERIC, so it never passes through floating point.
+type Amount int64
+
+// ParseAmount parses a decimal with at most two fractional digits.
+func ParseAmount(s string) (Amount, error) {
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if whole == "" || strings.TrimLeft(whole, "0123456789") != "" {
+		return 0, errors.Errorf("invalid amount %q", s)
+	}
+
+	if hasFraction && (fraction == "" || len(fraction) > 2 || strings.TrimLeft(fraction, "0123456789") != "") {
+		return 0, errors.Errorf("invalid amount %q: at most two fractional digits are allowed", s)
+	}
+
+	if len(strings.TrimLeft(whole, "0")) > maxAmountDigits {
+		return 0, errors.Errorf("amount %q is too large", s)
+	}
+
+	units, _ := strconv.ParseInt(whole, 10, 64)
+
+	cents := int64(0)
+	if fraction != "" {
+		cents, _ = strconv.ParseInt((fraction + "0")[:2], 10, 64)
+	}
+
+	return Amount(units*amountScale + cents), nil
+}
+
+func (a Amount) String() string {
+	return fmt.Sprintf("%d.%02d", int64(a)/amountScale, int64(a)%amountScale)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+// UnmarshalJSON accepts both "1500.50" and 1500.50.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	text := string(bytes.Trim(data, `"`))
+	amount, err := ParseAmount(text)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func (a *Amount) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case string:
+		return a.scanText(value)
+	case []byte:
+		return a.scanText(string(value))
+	case int64:
+		*a = Amount(value * amountScale)
+		return nil
+	default:
+		return errors.Errorf("cannot scan %T into Amount", src)
+	}
+}
+
+// scanText parses NUMERIC values, which may come with trailing zeros beyond the scale.
+func (a *Amount) scanText(text string) error {
+	if whole, fraction, ok := strings.Cut(text, "."); ok {
+		fraction = strings.TrimRight(fraction, "0")
+		text = whole
+		if fraction != "" {
+			text += "." + fraction
+		}
+	}
+
+	amount, err := ParseAmount(text)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Currency is an ISO 4217 currency code. The empty currency is stored as NULL.
+type Currency string
+
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Currency) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*c = ""
+	case string:
+		*c = Currency(value)
+	case []byte:
+		*c = Currency(value)
+	default:
+		return errors.Errorf("cannot scan %T into Currency", src)
+	}
+
+	return nil
+}
+
+func (c Currency) Value() (driver.Value, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	return string(c), nil
+}
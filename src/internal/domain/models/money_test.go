@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Amount
+	}{
+		{"0", 0},
+		{"1500", 150000},
+		{"1500.5", 150050},
+		{"1500.50", 150050},
+		{"0.07", 7},
+		{"007.10", 710},
+		{"9999999999999.99", 999999999999999},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.input)
+		if err != nil {
+			t.Errorf("ParseAmount(%q) error = %v", tt.input, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseAmountRejectsInvalid(t *testing.T) {
+	for _, input := range []string{"", ".5", "1.", "1.234", "-1", "+1", "1e3", "1,50", " 1", "12345678901234", "1.5.0"} {
+		if got, err := ParseAmount(input); err == nil {
+			t.Errorf("ParseAmount(%q) = %d, want an error", input, got)
+		}
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var amount Amount
+	if err := json.Unmarshal([]byte(`1500.5`), &amount); err != nil || amount != 150050 {
+		t.Errorf("unmarshal number = %d, %v, want 150050", amount, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"2400.00"`), &amount); err != nil || amount != 240000 {
+		t.Errorf("unmarshal string = %d, %v, want 240000", amount, err)
+	}
+
+	data, err := json.Marshal(Amount(150005))
+	if err != nil || string(data) != `"1500.05"` {
+		t.Errorf("marshal = %s, %v, want \"1500.05\"", data, err)
+	}
+}
+
+func TestAmountScan(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want Amount
+	}{
+		{"1500.50", 150050},
+		{[]byte("1500.500000"), 150050},
+		{"1500.00", 150000},
+		{"1500", 150000},
+		{int64(12), 1200},
+	}
+
+	for _, tt := range tests {
+		var amount Amount
+		if err := amount.Scan(tt.src); err != nil || amount != tt.want {
+			t.Errorf("Scan(%v) = %d, %v, want %d", tt.src, amount, err, tt.want)
+		}
+	}
+}
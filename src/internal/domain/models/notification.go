@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEvents are the event types employees are notified about.
+var NotificationEvents = []EventType{
+	EventBidPublished,
+	EventBidApproved,
+	EventBidRejected,
+	EventBidStale,
+	EventFeedbackAdded,
+	EventMessageSent,
+	EventTenderClosed,
+	EventQuestionAnswered,
+}
+
+func (t EventType) IsNotifiable() bool {
+	for _, eventType := range NotificationEvents {
+		if eventType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Notification struct {
+	ID         uuid.UUID  `db:"id" json:"id"`
+	EmployeeID uuid.UUID  `db:"employee_id" json:"employee_id"`
+	EventID    uuid.UUID  `db:"event_id" json:"event_id"`
+	EventType  EventType  `db:"event_type" json:"event_type"`
+	Payload    RawJSON    `db:"payload" json:"payload" swaggertype:"object"`
+	ReadAt     *time.Time `db:"read_at" json:"read_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+type NotificationPreference struct {
+	EventType EventType `db:"event_type" json:"event_type" binding:"required"`
+	Enabled   bool      `db:"enabled" json:"enabled"`
+}
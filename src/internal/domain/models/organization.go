@@ -13,4 +13,5 @@ type Organization struct {
 	Type        OrganizationType `db:"type" json:"type" binding:"required"`
 	CreatedAt   time.Time        `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time        `db:"updated_at" json:"updated_at"`
+	DeletedAt   *time.Time       `db:"deleted_at" json:"deleted_at,omitempty"`
 }
@@ -1,11 +1,26 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type OrganizationResponsible struct {
-	ID             uuid.UUID `db:"id" json:"id" binding:"required"`
-	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id" binding:"required"`
-	UserID         uuid.UUID `db:"user_id" json:"user_id" binding:"required"`
+	ID             uuid.UUID       `db:"id" json:"id" binding:"required"`
+	OrganizationID uuid.UUID       `db:"organization_id" json:"organization_id" binding:"required"`
+	UserID         uuid.UUID       `db:"user_id" json:"user_id" binding:"required"`
+	Username       string          `db:"username" json:"username"`
+	Role           ResponsibleRole `db:"role" json:"role"`
+	GrantedBy      *uuid.UUID      `db:"granted_by" json:"granted_by,omitempty"`
+	GrantedAt      time.Time       `db:"granted_at" json:"granted_at"`
+}
+
+type AddResponsibleRequest struct {
+	Username string          `json:"username" binding:"required"`
+	Role     ResponsibleRole `json:"role"`
+}
+
+type ChangeRoleRequest struct {
+	Role ResponsibleRole `json:"role" binding:"required"`
 }
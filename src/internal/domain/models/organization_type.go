@@ -7,3 +7,12 @@ const (
 	LLC OrganizationType = "LLC"
 	JSC OrganizationType = "JSC"
 )
+
+func (t OrganizationType) IsValid() bool {
+	switch t {
+	case IE, LLC, JSC:
+		return true
+	default:
+		return false
+	}
+}
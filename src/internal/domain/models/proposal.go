@@ -17,4 +17,54 @@ type Proposal struct {
 	Version        int       `db:"version" json:"version" binding:"required"`
 	CreatedAt      time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	Price          *Amount   `db:"price" json:"price,omitempty" swaggertype:"string" example:"2500.00"`
+	Currency       Currency  `db:"currency" json:"currency,omitempty" swaggertype:"string" example:"RUB"`
+	// SealedContent holds the encrypted ProposalContent of a bid on a sealed tender until the bids
+	// are opened; the plain content fields are empty meanwhile.
+	SealedContent []byte `db:"sealed_content" json:"-"`
+	Sealed        bool   `db:"sealed" json:"sealed,omitempty"`
+	// LotIDs are the lots the bid is for; bids on tenders with lots must name at least one.
+	LotIDs LotIDs `db:"lot_ids" json:"lot_ids,omitempty" swaggertype:"array,string"`
+	// TenderVersion is the version of the tender the bid was submitted or last revised against. The bid
+	// is stale once the tender is amended past it and can't be approved until the author acknowledges
+	// the amendment or revises the bid.
+	TenderVersion int  `db:"tender_version" json:"tender_version"`
+	Stale         bool `db:"stale" json:"stale"`
+}
+
+// ProposalContent is the part of a proposal hidden in sealed-bid tenders.
+type ProposalContent struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Price       *Amount  `json:"price,omitempty"`
+	Currency    Currency `json:"currency,omitempty"`
+}
+
+func (p *Proposal) Content() ProposalContent {
+	return ProposalContent{Title: p.Title, Description: p.Description, Price: p.Price, Currency: p.Currency}
+}
+
+func (p *Proposal) SetContent(content ProposalContent) {
+	p.Title = content.Title
+	p.Description = content.Description
+	p.Price = content.Price
+	p.Currency = content.Currency
+}
+
+// ProposalSort is the order of proposals in a tender's list.
+type ProposalSort string
+
+const (
+	ProposalSortCreated   ProposalSort = ""
+	ProposalSortPriceAsc  ProposalSort = "price_asc"
+	ProposalSortPriceDesc ProposalSort = "price_desc"
+)
+
+func (s ProposalSort) IsValid() bool {
+	switch s {
+	case ProposalSortCreated, ProposalSortPriceAsc, ProposalSortPriceDesc:
+		return true
+	}
+
+	return false
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Decision string
+
+const (
+	DecisionApproved Decision = "Approved"
+	DecisionRejected Decision = "Rejected"
+)
+
+func (d Decision) IsValid() bool {
+	return d == DecisionApproved || d == DecisionRejected
+}
+
+type ProposalDecision struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	ProposalID uuid.UUID `db:"proposal_id" json:"proposal_id"`
+	AuthorID   uuid.UUID `db:"author_id" json:"author_id"`
+	Decision   Decision  `db:"decision" json:"decision"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+type ProposalFeedback struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	ProposalID  uuid.UUID `db:"proposal_id" json:"proposal_id"`
+	AuthorID    uuid.UUID `db:"author_id" json:"author_id"`
+	Description string    `db:"description" json:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenderQuestion is a bidder's question on a tender. The asking organization and employee are kept
+// for the audit log but never shown, so bidders see the answers without learning who asked.
+type TenderQuestion struct {
+	ID               uuid.UUID  `db:"id" json:"id"`
+	TenderID         uuid.UUID  `db:"tender_id" json:"tender_id"`
+	OrganizationID   uuid.UUID  `db:"organization_id" json:"-"`
+	AskedBy          *uuid.UUID `db:"asked_by" json:"-"`
+	Question         string     `db:"question" json:"question"`
+	Answer           *string    `db:"answer" json:"answer"`
+	AnsweredBy       *uuid.UUID `db:"answered_by" json:"-"`
+	AnsweredAt       *time.Time `db:"answered_at" json:"answered_at"`
+	AmendmentVersion *int       `db:"amendment_version" json:"amendment_version,omitempty"`
+	AmendmentNote    *string    `db:"amendment_note" json:"amendment_note,omitempty"`
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+}
+
+type AskQuestionRequest struct {
+	OrganizationID uuid.UUID `json:"organization_id" binding:"required"`
+	Question       string    `json:"question" binding:"required"`
+}
+
+// AnswerQuestionRequest answers a question. An amendment note means the answer changes the terms
+// of the tender and creates a new tender version.
+type AnswerQuestionRequest struct {
+	Answer        string  `json:"answer" binding:"required"`
+	AmendmentNote *string `json:"amendment_note"`
+}
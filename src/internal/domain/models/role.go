@@ -0,0 +1,71 @@
+package models
+
+type ResponsibleRole string
+
+const (
+	RoleOwner         ResponsibleRole = "owner"
+	RoleTenderManager ResponsibleRole = "tender_manager"
+	RoleBidManager    ResponsibleRole = "bid_manager"
+	RoleReviewer      ResponsibleRole = "reviewer"
+	RoleViewer        ResponsibleRole = "viewer"
+)
+
+type Permission string
+
+const (
+	PermissionManageOrganization Permission = "organization.manage"
+	PermissionManageResponsibles Permission = "responsibles.manage"
+	PermissionViewResponsibles   Permission = "responsibles.view"
+	PermissionManageTenders      Permission = "tender.manage"
+	PermissionCloseTenders       Permission = "tender.close"
+	PermissionManageBids         Permission = "bid.manage"
+	PermissionViewBids           Permission = "bid.view"
+	PermissionDecideBids         Permission = "bid.decide"
+	PermissionLeaveFeedback      Permission = "bid.feedback"
+	PermissionScoreBids          Permission = "bid.score"
+	PermissionMessageBids        Permission = "bid.message"
+	PermissionManageWebhooks     Permission = "webhook.manage"
+)
+
+// rolePermissions is the permission matrix of organization responsibles.
+var rolePermissions = map[ResponsibleRole][]Permission{
+	RoleOwner: {
+		PermissionManageOrganization, PermissionManageResponsibles, PermissionViewResponsibles,
+		PermissionManageTenders, PermissionCloseTenders,
+		PermissionManageBids, PermissionViewBids, PermissionDecideBids, PermissionLeaveFeedback, PermissionScoreBids,
+		PermissionMessageBids, PermissionManageWebhooks,
+	},
+	RoleTenderManager: {
+		PermissionViewResponsibles,
+		PermissionManageTenders, PermissionCloseTenders,
+		PermissionViewBids, PermissionDecideBids, PermissionLeaveFeedback, PermissionScoreBids, PermissionMessageBids,
+	},
+	RoleBidManager: {
+		PermissionViewResponsibles,
+		PermissionManageBids, PermissionMessageBids,
+		PermissionManageWebhooks,
+	},
+	RoleReviewer: {
+		PermissionViewResponsibles,
+		PermissionViewBids, PermissionLeaveFeedback, PermissionScoreBids,
+	},
+	RoleViewer: {
+		PermissionViewResponsibles,
+		PermissionViewBids,
+	},
+}
+
+func (r ResponsibleRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r ResponsibleRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 type Tender struct {
@@ -17,4 +18,86 @@ type Tender struct {
 	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
 	ServiceType     string    `db:"service_type" json:"serviceType" binding:"required"`
 	CreatorUsername string    `db:"creator_username" json:"creatorUsername" binding:"required"`
+	// Bids are accepted until SubmissionDeadline; the tender is closed automatically at
+	// DecisionDeadline or, if it is not set, at SubmissionDeadline.
+	SubmissionDeadline *time.Time `db:"submission_deadline" json:"submissionDeadline,omitempty"`
+	DecisionDeadline   *time.Time `db:"decision_deadline" json:"decisionDeadline,omitempty"`
+	// BudgetMin and BudgetMax bound the price of bids; Currency is required when either is set.
+	BudgetMin *Amount  `db:"budget_min" json:"budgetMin,omitempty" swaggertype:"string" example:"1000.00"`
+	BudgetMax *Amount  `db:"budget_max" json:"budgetMax,omitempty" swaggertype:"string" example:"5000.00"`
+	Currency  Currency `db:"currency" json:"currency,omitempty" swaggertype:"string" example:"RUB"`
+	// Bids on a Sealed tender are stored encrypted and hidden from everyone but their authors
+	// until BidsOpenedAt, which is set at the submission deadline or when the bids are opened manually.
+	Sealed       bool       `db:"sealed" json:"sealed"`
+	BidsOpenedAt *time.Time `db:"bids_opened_at" json:"bidsOpenedAt,omitempty"`
+	// An AUCTION tender runs a reverse auction starting at AuctionStartsAt from BudgetMax: each bid must
+	// undercut the best one by at least MinDecrement. RoundDuration and SnipingExtension are in seconds.
+	Kind             TenderKind `db:"kind" json:"kind" example:"STANDARD"`
+	MinDecrement     *Amount    `db:"min_decrement" json:"minDecrement,omitempty" swaggertype:"string" example:"100.00"`
+	RoundDuration    *int       `db:"round_duration" json:"roundDuration,omitempty" example:"300"`
+	SnipingExtension *int       `db:"sniping_extension" json:"snipingExtension,omitempty" example:"60"`
+	AuctionStartsAt  *time.Time `db:"auction_starts_at" json:"auctionStartsAt,omitempty"`
+	// A PRIVATE tender is visible only to the responsibles of its organization and to invitees.
+	Visibility TenderVisibility `db:"visibility" json:"visibility" example:"PUBLIC"`
+}
+
+// AcceptsBids reports whether bids may still be created and published at the given time.
+func (t *Tender) AcceptsBids(now time.Time) bool {
+	return t.BidsOpenedAt == nil && (t.SubmissionDeadline == nil || now.Before(*t.SubmissionDeadline))
+}
+
+// BidsSealed reports whether the contents of the bids are still hidden.
+func (t *Tender) BidsSealed() bool {
+	return t.Sealed && t.BidsOpenedAt == nil
+}
+
+// ValidateBudget checks that the budget range is ordered and has a currency.
+func (t *Tender) ValidateBudget() error {
+	if t.Currency != "" && !t.Currency.IsValid() {
+		return errors.New("currency must be an ISO 4217 code")
+	}
+
+	if (t.BudgetMin != nil || t.BudgetMax != nil) && t.Currency == "" {
+		return errors.New("currency is required with a budget")
+	}
+
+	if t.BudgetMin != nil && t.BudgetMax != nil && *t.BudgetMax < *t.BudgetMin {
+		return errors.New("budgetMax must not be less than budgetMin")
+	}
+
+	return nil
+}
+
+// CheckPrice checks that a bid price fits the tender: bids on a tender with a budget must state
+// a price within it, in the tender currency.
+func (t *Tender) CheckPrice(price *Amount, currency Currency) error {
+	if currency != "" && !currency.IsValid() {
+		return errors.New("currency must be an ISO 4217 code")
+	}
+
+	if price == nil {
+		if t.BudgetMin != nil || t.BudgetMax != nil {
+			return errors.New("price is required for tenders with a budget")
+		}
+
+		return nil
+	}
+
+	if currency == "" {
+		return errors.New("currency is required with a price")
+	}
+
+	if t.Currency != "" && currency != t.Currency {
+		return errors.Errorf("price must be in %s", t.Currency)
+	}
+
+	if t.BudgetMin != nil && *price < *t.BudgetMin {
+		return errors.Errorf("price must not be less than %s", t.BudgetMin)
+	}
+
+	if t.BudgetMax != nil && *price > *t.BudgetMax {
+		return errors.Errorf("price must not exceed %s", t.BudgetMax)
+	}
+
+	return nil
 }
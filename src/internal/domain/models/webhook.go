@@ -0,0 +1,103 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	// DeliveryDead marks deliveries that failed every retry attempt.
+	DeliveryDead DeliveryStatus = "DEAD"
+)
+
+func (s DeliveryStatus) IsValid() bool {
+	return s == DeliveryPending || s == DeliveryDelivered || s == DeliveryDead
+}
+
+// EventTypeList is a list of event types stored as a comma-separated string.
+type EventTypeList []EventType
+
+func (l *EventTypeList) Scan(src interface{}) error {
+	var value string
+	switch src := src.(type) {
+	case nil:
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return errors.Errorf("cannot scan %T into EventTypeList", src)
+	}
+
+	*l = EventTypeList{}
+	for _, eventType := range strings.Split(value, ",") {
+		if eventType != "" {
+			*l = append(*l, EventType(eventType))
+		}
+	}
+
+	return nil
+}
+
+func (l EventTypeList) String() string {
+	values := make([]string, len(l))
+	for i, eventType := range l {
+		values[i] = string(eventType)
+	}
+
+	return strings.Join(values, ",")
+}
+
+type WebhookSubscription struct {
+	ID             uuid.UUID     `db:"id" json:"id"`
+	OrganizationID uuid.UUID     `db:"organization_id" json:"organization_id"`
+	URL            string        `db:"url" json:"url"`
+	Secret         string        `db:"secret" json:"secret,omitempty"`
+	EventTypes     EventTypeList `db:"event_types" json:"events" swaggertype:"array,string"`
+	CreatedBy      *uuid.UUID    `db:"created_by" json:"created_by"`
+	CreatedAt      time.Time     `db:"created_at" json:"created_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL string `json:"url" binding:"required"`
+	// Secret for HMAC signatures; generated if empty.
+	Secret string      `json:"secret"`
+	Events []EventType `json:"events" binding:"required"`
+}
+
+// WebhookDelivery is an event delivery to a subscription with the outcome of its last attempt.
+type WebhookDelivery struct {
+	ID             uuid.UUID      `db:"id" json:"id"`
+	SubscriptionID uuid.UUID      `db:"subscription_id" json:"subscription_id"`
+	EventID        uuid.UUID      `db:"event_id" json:"event_id"`
+	EventType      EventType      `db:"event_type" json:"event_type"`
+	Payload        RawJSON        `db:"payload" json:"payload" swaggertype:"object"`
+	Status         DeliveryStatus `db:"status" json:"status"`
+	Attempts       int            `db:"attempts" json:"attempts"`
+	NextAttemptAt  *time.Time     `db:"next_attempt_at" json:"next_attempt_at"`
+	LastAttemptAt  *time.Time     `db:"last_attempt_at" json:"last_attempt_at"`
+	ResponseStatus *int           `db:"response_status" json:"response_status"`
+	LastError      *string        `db:"last_error" json:"last_error"`
+	DeliveredAt    *time.Time     `db:"delivered_at" json:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+}
+
+// DueDelivery is a delivery claimed by the webhook worker together with its subscription's endpoint.
+type DueDelivery struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// DeliveryAttempt is the outcome of a single delivery attempt.
+type DeliveryAttempt struct {
+	ResponseStatus *int
+	Err            error
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"avito_2024/src/internal/domain/interface"
+)
+
+// Dispatcher periodically delivers pending outbox events through the publisher.
+type Dispatcher struct {
+	Outbox    _interface.OutboxRepository
+	Publisher Publisher
+	Policy    RetryPolicy
+	BatchSize int
+	Interval  time.Duration
+}
+
+func NewDispatcher(outbox _interface.OutboxRepository, publisher Publisher, policy RetryPolicy, batchSize int, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		Outbox:    outbox,
+		Publisher: publisher,
+		Policy:    policy,
+		BatchSize: batchSize,
+		Interval:  interval,
+	}
+}
+
+// Run dispatches events until the context is canceled. A full batch is followed by the next one
+// right away; otherwise the dispatcher waits for the interval. Failed events are retried with the
+// delays of the policy and dead-lettered after its last attempt.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := d.Outbox.ProcessPending(ctx, d.BatchSize, d.Publisher.Publish, d.retryAt)
+		if err != nil && ctx.Err() == nil {
+			log.Println("Error with dispatching events.", err)
+		}
+
+		if err == nil && published == d.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) retryAt(failedAttempts int) (time.Time, bool) {
+	if failedAttempts >= d.Policy.MaxAttempts {
+		return time.Time{}, false
+	}
+
+	return time.Now().Add(d.Policy.NextDelay(failedAttempts)), true
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+// MultiPublisher publishes every event through all its publishers and fails if any of them fails.
+// Publishers must tolerate repeated events, because the whole event is retried.
+type MultiPublisher []Publisher
+
+func (p MultiPublisher) Publish(ctx context.Context, event models.DomainEvent) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WebhookFanout queues deliveries of events to the organizations' webhook subscriptions.
+// Queuing is idempotent, so a retried event is not delivered twice.
+type WebhookFanout struct {
+	Deliveries _interface.WebhookRepository
+}
+
+func NewWebhookFanout(deliveries _interface.WebhookRepository) *WebhookFanout {
+	return &WebhookFanout{Deliveries: deliveries}
+}
+
+func (p *WebhookFanout) Publish(ctx context.Context, event models.DomainEvent) error {
+	if _, err := p.Deliveries.EnqueueDeliveries(ctx, event); err != nil {
+		return errors.Wrap(err, "failed to fan out event to webhooks")
+	}
+
+	return nil
+}
+
+// NotificationFanout adds events to the inboxes of the employees they concern.
+// Notifications are unique per event, so a retried event is not added twice.
+type NotificationFanout struct {
+	Notifications _interface.NotificationRepository
+}
+
+func NewNotificationFanout(notifications _interface.NotificationRepository) *NotificationFanout {
+	return &NotificationFanout{Notifications: notifications}
+}
+
+func (p *NotificationFanout) Publish(ctx context.Context, event models.DomainEvent) error {
+	if _, err := p.Notifications.CreateForEvent(ctx, event); err != nil {
+		return errors.Wrap(err, "failed to fan out event to notifications")
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+// MessageWriter is the part of kafka.Writer the publisher uses, so that it can be replaced with a fake.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+}
+
+func NewKafkaWriter(brokers []string, topic string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: 10 * time.Millisecond,
+	}
+}
+
+// KafkaPublisher writes events to a Kafka-compatible broker. Messages are keyed by the aggregate ID,
+// so the events of one tender or proposal stay in order within a partition.
+type KafkaPublisher struct {
+	Writer MessageWriter
+}
+
+func NewKafkaPublisher(writer MessageWriter) *KafkaPublisher {
+	return &KafkaPublisher{Writer: writer}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, event models.DomainEvent) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	err = p.Writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AggregateID.String()),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(event.ID.String())},
+			{Key: "event_type", Value: []byte(event.Type)},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to write kafka message")
+	}
+
+	return nil
+}
+
+// FakeKafkaWriter keeps written messages in memory. It stands in for a broker in local runs and tests.
+type FakeKafkaWriter struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+	Err      error
+}
+
+func NewFakeKafkaWriter() *FakeKafkaWriter {
+	return &FakeKafkaWriter{}
+}
+
+func (w *FakeKafkaWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Err != nil {
+		return w.Err
+	}
+
+	w.messages = append(w.messages, messages...)
+
+	return nil
+}
+
+// Messages returns a copy of the messages written so far.
+func (w *FakeKafkaWriter) Messages() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]kafka.Message(nil), w.messages...)
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+func TestKafkaPublisherWritesEventKeyedByAggregate(t *testing.T) {
+	writer := NewFakeKafkaWriter()
+	publisher := NewKafkaPublisher(writer)
+
+	event := models.DomainEvent{
+		ID:            uuid.New(),
+		Type:          models.EventBidApproved,
+		AggregateType: "proposal",
+		AggregateID:   uuid.New(),
+		Payload:       models.RawJSON(`{"status":"Approved"}`),
+		CreatedAt:     time.Date(2024, 9, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	messages := writer.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+
+	message := messages[0]
+	if string(message.Key) != event.AggregateID.String() {
+		t.Errorf("key = %q, want aggregate ID %q", message.Key, event.AggregateID)
+	}
+
+	headers := make(map[string]string)
+	for _, header := range message.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+
+	if headers["event_id"] != event.ID.String() || headers["event_type"] != string(event.Type) {
+		t.Errorf("headers = %v, want event_id %s and event_type %s", headers, event.ID, event.Type)
+	}
+
+	var decoded struct {
+		ID          uuid.UUID        `json:"id"`
+		Type        models.EventType `json:"type"`
+		AggregateID uuid.UUID        `json:"aggregate_id"`
+		Payload     json.RawMessage  `json:"payload"`
+		OccurredAt  time.Time        `json:"occurred_at"`
+	}
+	if err := json.Unmarshal(message.Value, &decoded); err != nil {
+		t.Fatalf("message value is not an event: %v", err)
+	}
+
+	if decoded.ID != event.ID || decoded.Type != event.Type || decoded.AggregateID != event.AggregateID ||
+		!decoded.OccurredAt.Equal(event.CreatedAt) || string(decoded.Payload) != string(event.Payload) {
+		t.Errorf("decoded event = %+v, want %+v", decoded, event)
+	}
+}
+
+func TestKafkaPublisherReturnsWriterError(t *testing.T) {
+	writer := NewFakeKafkaWriter()
+	writer.Err = errors.New("broker is unavailable")
+	publisher := NewKafkaPublisher(writer)
+
+	err := publisher.Publish(context.Background(), models.DomainEvent{ID: uuid.New(), Type: models.EventTenderClosed, AggregateID: uuid.New(), Payload: models.RawJSON(`{}`)})
+	if err == nil {
+		t.Fatal("Publish() error = nil, want the writer error")
+	}
+
+	if len(writer.Messages()) != 0 {
+		t.Errorf("failed write stored %d messages", len(writer.Messages()))
+	}
+}
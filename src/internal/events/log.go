@@ -0,0 +1,29 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+// LogPublisher writes events to the application log.
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event models.DomainEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	log.Printf("event %s", data)
+
+	return nil
+}
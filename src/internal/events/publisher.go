@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+// Publisher kinds accepted in EVENTS_PUBLISHER.
+const (
+	PublisherLog     = "log"
+	PublisherWebhook = "webhook"
+	PublisherKafka   = "kafka"
+)
+
+// Publisher delivers domain events to downstream systems. Delivery is at least once,
+// so consumers should deduplicate events by ID.
+type Publisher interface {
+	Publish(ctx context.Context, event models.DomainEvent) error
+}
+
+// NewPublisherFromEnv creates the publisher chosen by EVENTS_PUBLISHER, log by default.
+func NewPublisherFromEnv() (Publisher, error) {
+	kind := os.Getenv("EVENTS_PUBLISHER")
+
+	switch strings.ToLower(kind) {
+	case PublisherLog, "":
+		return NewLogPublisher(), nil
+	case PublisherWebhook:
+		url := os.Getenv("EVENTS_WEBHOOK_URL")
+		if url == "" {
+			return nil, errors.New("EVENTS_WEBHOOK_URL is not set")
+		}
+
+		return NewWebhookPublisher(url, 10*time.Second), nil
+	case PublisherKafka:
+		brokers := os.Getenv("EVENTS_KAFKA_BROKERS")
+		if brokers == "" {
+			return nil, errors.New("EVENTS_KAFKA_BROKERS is not set")
+		}
+
+		topic := os.Getenv("EVENTS_KAFKA_TOPIC")
+		if topic == "" {
+			topic = "avito.events"
+		}
+
+		return NewKafkaPublisher(NewKafkaWriter(strings.Split(brokers, ","), topic)), nil
+	default:
+		return nil, errors.Errorf("unknown event publisher %q", kind)
+	}
+}
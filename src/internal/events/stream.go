@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+// streamBuffer is the number of events a subscriber may lag behind before it is disconnected.
+const streamBuffer = 64
+
+// StreamSubscription receives the events visible to an employee. Events is closed when the
+// subscriber falls behind, and the client is expected to reconnect.
+type StreamSubscription struct {
+	UserID uuid.UUID
+	Events chan models.DomainEvent
+}
+
+// StreamHub fans events announced by the database out to the connected event streams.
+type StreamHub struct {
+	Events _interface.OutboxRepository
+
+	mu          sync.Mutex
+	subscribers map[*StreamSubscription]struct{}
+}
+
+func NewStreamHub(events _interface.OutboxRepository) *StreamHub {
+	return &StreamHub{
+		Events:      events,
+		subscribers: make(map[*StreamSubscription]struct{}),
+	}
+}
+
+func (h *StreamHub) Subscribe(userID uuid.UUID) *StreamSubscription {
+	subscription := &StreamSubscription{
+		UserID: userID,
+		Events: make(chan models.DomainEvent, streamBuffer),
+	}
+
+	h.mu.Lock()
+	h.subscribers[subscription] = struct{}{}
+	h.mu.Unlock()
+
+	return subscription
+}
+
+func (h *StreamHub) Unsubscribe(subscription *StreamSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[subscription]; ok {
+		delete(h.subscribers, subscription)
+		close(subscription.Events)
+	}
+}
+
+// Notify loads the announced event and sends it to the subscribers allowed to see it.
+// The payload is the ID of the outbox event.
+func (h *StreamHub) Notify(ctx context.Context, payload string) {
+	eventID, err := uuid.Parse(payload)
+	if err != nil {
+		log.Println("Error with parsing event notification.", err)
+		return
+	}
+
+	h.mu.Lock()
+	idle := len(h.subscribers) == 0
+	h.mu.Unlock()
+
+	if idle {
+		return
+	}
+
+	event, err := h.Events.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error with loading streamed event.", err)
+		return
+	}
+
+	public, audience, err := h.Events.GetEventAudience(ctx, *event)
+	if err != nil {
+		log.Println("Error with loading streamed event audience.", err)
+		return
+	}
+
+	allowed := make(map[uuid.UUID]bool, len(audience))
+	for _, userID := range audience {
+		allowed[userID] = true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscribers {
+		if !public && !allowed[subscription.UserID] {
+			continue
+		}
+
+		select {
+		case subscription.Events <- *event:
+		default:
+			delete(h.subscribers, subscription)
+			close(subscription.Events)
+		}
+	}
+}
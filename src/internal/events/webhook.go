@@ -0,0 +1,58 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+// WebhookPublisher POSTs every event as JSON to a single URL. Any non-2xx response is a failure.
+type WebhookPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{
+		URL: url,
+		Client: &http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+	}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event models.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook request")
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-ID", event.ID.String())
+	request.Header.Set("X-Event-Type", string(event.Type))
+
+	response, err := p.Client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to send webhook")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+// Headers of webhook requests.
+const (
+	SignatureHeader  = "X-Webhook-Signature"
+	TimestampHeader  = "X-Webhook-Timestamp"
+	DeliveryIDHeader = "X-Webhook-Delivery"
+)
+
+// RetryPolicy doubles the delay after every failed attempt, from BaseDelay up to MaxDelay.
+// A delivery that fails MaxAttempts times is dead-lettered.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NextDelay returns the delay before the attempt following the given number of failed attempts.
+func (p RetryPolicy) NextDelay(failedAttempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < failedAttempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
+
+// Sign returns the HMAC-SHA256 signature of the timestamp and body, "sha256=<hex>".
+// Receivers recompute it over "<timestamp>.<body>" with the subscription secret.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookPayload struct {
+	ID         uuid.UUID        `json:"id"`
+	Type       models.EventType `json:"type"`
+	DeliveryID uuid.UUID        `json:"delivery_id"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Data       models.RawJSON   `json:"data"`
+}
+
+// WebhookWorker delivers queued webhook deliveries and retries the failed ones.
+type WebhookWorker struct {
+	Deliveries _interface.WebhookRepository
+	Client     *http.Client
+	Policy     RetryPolicy
+	BatchSize  int
+	Interval   time.Duration
+}
+
+func NewWebhookWorker(deliveries _interface.WebhookRepository, policy RetryPolicy, timeout time.Duration, batchSize int, interval time.Duration) *WebhookWorker {
+	return &WebhookWorker{
+		Deliveries: deliveries,
+		Client: &http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		Policy:    policy,
+		BatchSize: batchSize,
+		Interval:  interval,
+	}
+}
+
+// Run delivers due deliveries until the context is canceled.
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := w.deliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Println("Error with delivering webhooks.", err)
+		}
+
+		if err == nil && claimed == w.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *WebhookWorker) deliverDue(ctx context.Context) (int, error) {
+	// Claimed deliveries are hidden from other workers for longer than a batch of attempts may take.
+	lease := time.Duration(w.BatchSize+1) * w.Client.Timeout
+	deliveries, err := w.Deliveries.ClaimDueDeliveries(ctx, w.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		attempt := w.send(ctx, delivery)
+
+		status, nextAttemptAt := models.DeliveryDelivered, (*time.Time)(nil)
+		if attempt.Err != nil {
+			status = models.DeliveryDead
+			if failed := delivery.Attempts + 1; failed < w.Policy.MaxAttempts {
+				status = models.DeliveryPending
+				next := time.Now().Add(w.Policy.NextDelay(failed))
+				nextAttemptAt = &next
+			}
+		}
+
+		if err = w.Deliveries.RecordAttempt(ctx, delivery.ID, status, attempt, nextAttemptAt); err != nil {
+			return len(deliveries), err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (w *WebhookWorker) send(ctx context.Context, delivery models.DueDelivery) models.DeliveryAttempt {
+	body, err := json.Marshal(webhookPayload{
+		ID:         delivery.EventID,
+		Type:       delivery.EventType,
+		DeliveryID: delivery.ID,
+		CreatedAt:  delivery.CreatedAt,
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		return models.DeliveryAttempt{Err: errors.Wrap(err, "failed to marshal webhook payload")}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return models.DeliveryAttempt{Err: errors.Wrap(err, "failed to create webhook request")}
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, body))
+	request.Header.Set(DeliveryIDHeader, delivery.ID.String())
+	request.Header.Set("X-Event-ID", delivery.EventID.String())
+	request.Header.Set("X-Event-Type", string(delivery.EventType))
+
+	response, err := w.Client.Do(request)
+	if err != nil {
+		return models.DeliveryAttempt{Err: errors.Wrap(err, "failed to send webhook")}
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	status := response.StatusCode
+	if status < 200 || status >= 300 {
+		return models.DeliveryAttempt{ResponseStatus: &status, Err: errors.Errorf("webhook responded with status %d", status)}
+	}
+
+	return models.DeliveryAttempt{ResponseStatus: &status}
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// MemoryStore is a Store that keeps buckets in process memory.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryStore) TakeAll(_ context.Context, keys []string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	buckets := make([]*bucket, 0, len(keys))
+	allowed, wait := true, time.Duration(0)
+	for _, key := range keys {
+		b, ok := s.buckets[key]
+		if !ok {
+			b = &bucket{tokens: float64(limit.Burst), updated: now, limit: limit}
+			s.buckets[key] = b
+		}
+
+		b.refill(now)
+		buckets = append(buckets, b)
+
+		if b.tokens < 1 {
+			allowed = false
+			wait = max(wait, time.Duration((1-b.tokens)/limit.Rate*float64(time.Second)))
+		}
+	}
+
+	if !allowed {
+		return Result{Allowed: false, RetryAfter: wait}, nil
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	return Result{Allowed: true}, nil
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+	b.updated = now
+}
+
+// sweep drops buckets that have refilled completely, so idle clients don't accumulate.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
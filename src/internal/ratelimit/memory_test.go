@@ -0,0 +1,33 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreTakeAllTakesFromNoneWhenOneIsEmpty(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	limit := Limit{Rate: 0.001, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		if result, err := store.TakeAll(ctx, []string{"ip:1"}, limit); err != nil || !result.Allowed {
+			t.Fatalf("take %d from ip:1 = %+v, %v, want allowed", i+1, result, err)
+		}
+	}
+
+	result, err := store.TakeAll(ctx, []string{"user:alice", "ip:1"}, limit)
+	if err != nil || result.Allowed || result.RetryAfter <= 0 {
+		t.Fatalf("TakeAll() with an empty bucket = %+v, %v, want rejected with a retry delay", result, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if result, err := store.TakeAll(ctx, []string{"user:alice"}, limit); err != nil || !result.Allowed {
+			t.Errorf("take %d from user:alice = %+v, %v, want allowed: the rejected request must not use its tokens", i+1, result, err)
+		}
+	}
+
+	if result, _ := store.TakeAll(ctx, []string{"user:alice"}, limit); result.Allowed {
+		t.Error("take 3 from user:alice allowed, want the burst to be used up")
+	}
+}
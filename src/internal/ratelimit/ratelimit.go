@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Limit describes a token bucket: Rate tokens are added per second up to Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets. The in-memory implementation is local to one instance;
+// a shared implementation (e.g. Redis or PostgreSQL) makes limits hold across instances.
+type Store interface {
+	// TakeAll takes a token from every bucket or, if any of them is empty, from none of them,
+	// so a request rejected by one bucket does not use up the others.
+	TakeAll(ctx context.Context, keys []string, limit Limit) (Result, error)
+}
+
+// LoadLimit reads <prefix>_RPS and <prefix>_BURST from the environment, keeping
+// the fallback values for variables that are unset or invalid.
+func LoadLimit(prefix string, fallback Limit) Limit {
+	limit := fallback
+
+	if rate, err := strconv.ParseFloat(os.Getenv(prefix+"_RPS"), 64); err == nil && rate > 0 {
+		limit.Rate = rate
+	}
+
+	if burst, err := strconv.Atoi(os.Getenv(prefix + "_BURST")); err == nil && burst > 0 {
+		limit.Burst = burst
+	}
+
+	return limit
+}
@@ -0,0 +1,196 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type AttachmentRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAttachmentRepository(db *sqlx.DB) _interface.AttachmentRepository {
+	return &AttachmentRepository{
+		DB: db,
+	}
+}
+
+// CreateAttachment locks the tender or proposal, so that concurrent uploads of a file get distinct
+// revisions and the version can't change while the file is recorded.
+func (repo *AttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	owner, err := lockAttachmentOwner(ctx, tx, attachment.EntityType, attachment.EntityID)
+	if err != nil {
+		return err
+	}
+
+	attachment.TenderID = owner.tenderID
+	attachment.Version = owner.version
+	attachment.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO attachment (id, entity_type, entity_id, tender_id, file_name, content_type, size, checksum_sha256, storage_key, sealed, version, revision, uploaded_by, created_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE(MAX(revision), 0) + 1, $12, $13
+		FROM attachment
+		WHERE entity_type = $2 AND entity_id = $3 AND file_name = $5
+		RETURNING revision
+	`
+
+	spanCtx, span := startSpan(ctx, "AttachmentRepository.CreateAttachment", query)
+	err = tx.GetContext(spanCtx, &attachment.Revision, query, attachment.ID, string(attachment.EntityType), attachment.EntityID, attachment.TenderID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.Checksum, attachment.StorageKey, attachment.Sealed, attachment.Version, attachment.UploadedBy, attachment.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to create attachment")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityAttachment,
+		EntityID:       attachment.ID,
+		OrganizationID: owner.organizationID,
+		After:          attachment,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *AttachmentRepository) GetAttachments(ctx context.Context, entityType models.AttachmentEntity, entityID uuid.UUID, version *int) ([]models.Attachment, error) {
+	query := `
+		SELECT id, entity_type, entity_id, tender_id, file_name, content_type, size, checksum_sha256, storage_key, sealed, version, revision, uploaded_by, created_at, deleted_version, deleted_at
+		FROM (
+			SELECT DISTINCT ON (file_name) *
+			FROM attachment
+			WHERE entity_type = $1 AND entity_id = $2 AND ($3::int IS NULL OR version <= $3::int)
+			ORDER BY file_name, revision DESC
+		) latest
+		WHERE deleted_version IS NULL OR deleted_version > $3::int
+		ORDER BY file_name
+	`
+
+	attachments := []models.Attachment{}
+	ctx, span := startSpan(ctx, "AttachmentRepository.GetAttachments", query)
+	err := repo.DB.SelectContext(ctx, &attachments, query, string(entityType), entityID, version)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachments")
+	}
+
+	return attachments, nil
+}
+
+func (repo *AttachmentRepository) GetAttachment(ctx context.Context, entityType models.AttachmentEntity, entityID uuid.UUID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	query := `
+		SELECT id, entity_type, entity_id, tender_id, file_name, content_type, size, checksum_sha256, storage_key, sealed, version, revision, uploaded_by, created_at, deleted_version, deleted_at
+		FROM attachment
+		WHERE id = $1 AND entity_type = $2 AND entity_id = $3
+	`
+
+	var attachment models.Attachment
+	ctx, span := startSpan(ctx, "AttachmentRepository.GetAttachment", query)
+	err := repo.DB.GetContext(ctx, &attachment, query, attachmentID, string(entityType), entityID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachment")
+	}
+
+	return &attachment, nil
+}
+
+func (repo *AttachmentRepository) DeleteAttachment(ctx context.Context, entityType models.AttachmentEntity, entityID uuid.UUID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	owner, err := lockAttachmentOwner(ctx, tx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the latest revision of a file can be deleted: older ones are already replaced.
+	query := `
+		UPDATE attachment a
+		SET deleted_version = $4, deleted_at = $5
+		WHERE a.id = $1 AND a.entity_type = $2 AND a.entity_id = $3 AND a.deleted_version IS NULL AND NOT EXISTS (
+			SELECT 1
+			FROM attachment newer
+			WHERE newer.entity_type = a.entity_type AND newer.entity_id = a.entity_id AND newer.file_name = a.file_name AND newer.revision > a.revision
+		)
+		RETURNING a.id, a.entity_type, a.entity_id, a.tender_id, a.file_name, a.content_type, a.size, a.checksum_sha256, a.storage_key, a.sealed, a.version, a.revision,
+			a.uploaded_by, a.created_at, a.deleted_version, a.deleted_at
+	`
+
+	var attachment models.Attachment
+	spanCtx, span := startSpan(ctx, "AttachmentRepository.DeleteAttachment", query)
+	err = tx.GetContext(spanCtx, &attachment, query, attachmentID, string(entityType), entityID, owner.version, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete attachment")
+	}
+
+	before := attachment
+	before.DeletedVersion, before.DeletedAt = nil, nil
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "delete",
+		EntityType:     auditEntityAttachment,
+		EntityID:       attachment.ID,
+		OrganizationID: owner.organizationID,
+		Before:         before,
+		After:          attachment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &attachment, nil
+}
+
+// attachmentOwner is the locked tender or proposal files are attached to.
+type attachmentOwner struct {
+	tenderID       uuid.UUID
+	organizationID uuid.UUID
+	version        int
+}
+
+func lockAttachmentOwner(ctx context.Context, tx *sqlx.Tx, entityType models.AttachmentEntity, entityID uuid.UUID) (*attachmentOwner, error) {
+	if entityType == models.AttachmentProposal {
+		proposal, err := lockProposal(ctx, tx, entityID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &attachmentOwner{tenderID: proposal.TenderID, organizationID: proposal.OrganizationID, version: proposal.Version}, nil
+	}
+
+	tender, err := lockTender(ctx, tx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachmentOwner{tenderID: tender.ID, organizationID: tender.OrganizationID, version: tender.Version}, nil
+}
@@ -0,0 +1,184 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuctionRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAuctionRepository(db *sqlx.DB) _interface.AuctionRepository {
+	return &AuctionRepository{
+		DB: db,
+	}
+}
+
+// PlaceBid accepts an auction bid. The tender row lock puts concurrent bids in a strict order: each
+// bid is checked against the best price left by the bids accepted before it.
+func (repo *AuctionRepository) PlaceBid(ctx context.Context, bid *models.AuctionBid) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	// Proposal before tender, in the lock order of SubmitDecision.
+	proposal, err := lockProposal(ctx, tx, bid.ProposalID)
+	if err != nil {
+		return err
+	}
+
+	if proposal.Status != "PUBLISHED" {
+		return errors.Wrap(models.ErrInvalidState, "only published proposals can bid")
+	}
+
+	tender, err := lockTender(ctx, tx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+
+	if tender.Kind != models.TenderAuction {
+		return errors.Wrap(models.ErrInvalidState, "tender is not an auction")
+	}
+
+	if tender.Status != "PUBLISHED" {
+		return errors.Wrap(models.ErrInvalidState, "tender is not published")
+	}
+
+	now := time.Now()
+	if now.Before(*tender.AuctionStartsAt) {
+		return errors.Wrap(models.ErrInvalidState, "auction has not started")
+	}
+
+	auction, err := getAuction(ctx, tx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+
+	auction.Advance(now, tender)
+	if auction.Ended {
+		return errors.Wrap(models.ErrInvalidState, "auction has ended")
+	}
+
+	if err = auction.CheckBid(bid.Price, tender); err != nil {
+		return errors.Wrap(models.ErrInvalidState, err.Error())
+	}
+
+	bid.ID = uuid.New()
+	bid.CreatedAt = now
+	auction.Accept(bid, tender)
+
+	query := `
+		INSERT INTO auction_bid (id, tender_id, sequence, proposal_id, round, price, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	spanCtx, span := startSpan(ctx, "AuctionRepository.PlaceBid", query)
+	_, err = tx.ExecContext(spanCtx, query, bid.ID, bid.TenderID, bid.Sequence, bid.ProposalID, bid.Round, bid.Price, bid.Currency, bid.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to create auction bid")
+	}
+
+	query = `
+		UPDATE auction
+		SET round = $2, round_ends_at = $3, round_bids = $4, best_price = $5, last_sequence = $6
+		WHERE tender_id = $1
+	`
+
+	spanCtx, span = startSpan(ctx, "AuctionRepository.PlaceBid", query)
+	_, err = tx.ExecContext(spanCtx, query, bid.TenderID, auction.Round, auction.RoundEndsAt, auction.RoundBids, auction.BestPrice, auction.LastSequence)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to update auction")
+	}
+
+	// The proposal follows its latest auction bid, so price sorting and ranking see the auction result.
+	query = `
+		UPDATE proposal
+		SET price = $2, currency = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	spanCtx, span = startSpan(ctx, "AuctionRepository.PlaceBid", query)
+	_, err = tx.ExecContext(spanCtx, query, bid.ProposalID, bid.Price, bid.Currency, now)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to update proposal price")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityAuctionBid,
+		EntityID:       bid.ID,
+		OrganizationID: tender.OrganizationID,
+		After:          bid,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *AuctionRepository) GetLeaderboard(ctx context.Context, tenderID uuid.UUID) (*models.Leaderboard, error) {
+	auction, err := getAuction(ctx, repo.DB, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every accepted bid undercuts all earlier ones, so the lowest bid of a proposal is also its latest.
+	query := `
+		SELECT b.proposal_id, MIN(b.price) AS price, MIN(b.currency) AS currency, COUNT(*) AS bids, MAX(b.created_at) AS bid_at
+		FROM auction_bid b
+		JOIN proposal p ON b.proposal_id = p.id
+		WHERE b.tender_id = $1 AND p.status = 'PUBLISHED'
+		GROUP BY b.proposal_id
+		ORDER BY price, bid_at
+	`
+
+	entries := []models.LeaderboardEntry{}
+	spanCtx, span := startSpan(ctx, "AuctionRepository.GetLeaderboard", query)
+	err = repo.DB.SelectContext(spanCtx, &entries, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get auction leaderboard")
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	return &models.Leaderboard{Auction: *auction, Entries: entries}, nil
+}
+
+func getAuction(ctx context.Context, db sqlx.QueryerContext, tenderID uuid.UUID) (*models.Auction, error) {
+	query := `
+		SELECT tender_id, round, round_ends_at, round_bids, best_price, last_sequence
+		FROM auction
+		WHERE tender_id = $1
+	`
+
+	var auction models.Auction
+	ctx, span := startSpan(ctx, "getAuction", query)
+	err := sqlx.GetContext(ctx, db, &auction, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get auction")
+	}
+
+	return &auction, nil
+}
@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+	"avito_2024/src/internal/requestid"
+)
+
+// Audited entity types.
+const (
+	auditEntityTender           = "tender"
+	auditEntityProposal         = "proposal"
+	auditEntityProposalFeedback = "proposal_feedback"
+	auditEntityTenderCriteria   = "tender_criteria"
+	auditEntityProposalScore    = "proposal_score"
+	auditEntityAuctionBid       = "auction_bid"
+	auditEntityTenderLots       = "tender_lots"
+	auditEntityTenderLot        = "tender_lot"
+	auditEntityTenderInvitation = "tender_invitation"
+	auditEntityAttachment       = "attachment"
+	auditEntityTenderQuestion   = "tender_question"
+	auditEntityProposalMessage  = "proposal_message"
+)
+
+// auditChange describes a single mutation to be recorded in the audit log.
+type auditChange struct {
+	Action         string
+	EntityType     string
+	EntityID       uuid.UUID
+	OrganizationID uuid.UUID
+	Before         interface{}
+	After          interface{}
+}
+
+// writeAuditEvent appends the change to the audit log within the mutation's transaction,
+// taking the actor and request ID from the context.
+func writeAuditEvent(ctx context.Context, tx *sqlx.Tx, change auditChange) error {
+	before, err := marshalAuditState(change.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := marshalAuditState(change.After)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_event (id, actor, action, entity_type, entity_id, organization_id, before, after, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	spanCtx, span := startSpan(ctx, "writeAuditEvent", query)
+	_, err = tx.ExecContext(spanCtx, query, uuid.New(), nullString(audit.ActorFromContext(ctx)), change.Action, change.EntityType,
+		change.EntityID, change.OrganizationID, before, after, nullString(requestid.FromContext(ctx)), time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to write audit event")
+	}
+
+	return nil
+}
+
+func marshalAuditState(state interface{}) (*string, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal audit state")
+	}
+
+	value := string(data)
+	return &value, nil
+}
+
+func nullString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+type AuditRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) _interface.AuditRepository {
+	return &AuditRepository{
+		DB: db,
+	}
+}
+
+// GetAuditEvents returns audit events of the organizations the user is responsible for, newest first.
+func (repo *AuditRepository) GetAuditEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error) {
+	conditions := []string{`ae.organization_id IN (
+			SELECT org_res.organization_id
+			FROM organization_responsible org_res
+			JOIN employee e ON org_res.user_id = e.id
+			WHERE e.username = $1
+		)`}
+	args := []interface{}{filter.Username}
+
+	if filter.EntityID != nil {
+		args = append(args, *filter.EntityID)
+		conditions = append(conditions, fmt.Sprintf("ae.entity_id = $%d", len(args)))
+	}
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		conditions = append(conditions, fmt.Sprintf("ae.actor = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("ae.created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("ae.created_at <= $%d", len(args)))
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT ae.id, ae.actor, ae.action, ae.entity_type, ae.entity_id, ae.organization_id,
+			ae.before::text AS before, ae.after::text AS after, ae.request_id, ae.created_at
+		FROM audit_event ae
+		WHERE %s
+		ORDER BY ae.created_at DESC, ae.id
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	events := []models.AuditEvent{}
+	ctx, span := startSpan(ctx, "AuditRepository.GetAuditEvents", query)
+	err := repo.DB.SelectContext(ctx, &events, query, args...)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get audit events")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,89 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type AuthRepository struct {
+	DB *sqlx.DB
+}
+
+func NewAuthRepository(db *sqlx.DB) *AuthRepository {
+	return &AuthRepository{
+		DB: db,
+	}
+}
+
+func (repo *AuthRepository) GetCredentials(ctx context.Context, username string) (*models.EmployeeCredentials, error) {
+	query := `
+		SELECT id, username, password_hash, password_reset_hash, password_reset_expires_at, token_version
+		FROM employee
+		WHERE username = $1 AND deactivated_at IS NULL
+	`
+
+	var credentials models.EmployeeCredentials
+	ctx, span := startSpan(ctx, "AuthRepository.GetCredentials", query)
+	err := repo.DB.GetContext(ctx, &credentials, query, username)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee credentials")
+	}
+
+	return &credentials, nil
+}
+
+// SetPassword replaces the password, drops the reset token and revokes the issued tokens, provided neither
+// the password nor the reset token has changed since the credentials were read: a reset token can be used only once.
+func (repo *AuthRepository) SetPassword(ctx context.Context, credentials *models.EmployeeCredentials, passwordHash string) error {
+	query := `
+		UPDATE employee
+		SET password_hash = $2, password_reset_hash = NULL, password_reset_expires_at = NULL, token_version = token_version + 1, updated_at = $5
+		WHERE id = $1 AND deactivated_at IS NULL
+			AND password_hash IS NOT DISTINCT FROM $3 AND password_reset_hash IS NOT DISTINCT FROM $4
+	`
+
+	ctx, span := startSpan(ctx, "AuthRepository.SetPassword", query)
+	result, err := repo.DB.ExecContext(ctx, query, credentials.ID, passwordHash, credentials.PasswordHash, credentials.PasswordResetHash, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to set password")
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to set password")
+	}
+
+	if updated == 0 {
+		return errors.Wrap(sql.ErrNoRows, "failed to set password")
+	}
+
+	return nil
+}
+
+// GetTokenVersion returns the current token version of an active employee.
+func (repo *AuthRepository) GetTokenVersion(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT token_version
+		FROM employee
+		WHERE id = $1 AND deactivated_at IS NULL
+	`
+
+	var version int
+	ctx, span := startSpan(ctx, "AuthRepository.GetTokenVersion", query)
+	err := repo.DB.GetContext(ctx, &version, query, userID)
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get token version")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type EmployeeRepository struct {
+	DB *sqlx.DB
+}
+
+func NewEmployeeRepository(db *sqlx.DB) *EmployeeRepository {
+	return &EmployeeRepository{
+		DB: db,
+	}
+}
+
+func (repo *EmployeeRepository) CreateEmployee(ctx context.Context, employee *models.Employee, passwordHash string) error {
+	query := `
+		INSERT INTO employee (id, username, first_name, last_name, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	employee.ID = uuid.New()
+	employee.CreatedAt = time.Now()
+	employee.UpdatedAt = employee.CreatedAt
+	employee.DeactivatedAt = nil
+
+	ctx, span := startSpan(ctx, "EmployeeRepository.CreateEmployee", query)
+	_, err := repo.DB.ExecContext(ctx, query, employee.ID, employee.Username, employee.FirstName, employee.LastName, passwordHash, employee.CreatedAt, employee.UpdatedAt)
+	endSpan(span, err)
+	if isUniqueViolation(err) {
+		return errors.Wrap(models.ErrAlreadyExists, "employee with this username")
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create employee")
+	}
+
+	return nil
+}
+
+func (repo *EmployeeRepository) GetEmployeeByUsername(ctx context.Context, username string) (*models.Employee, error) {
+	query := `
+		SELECT id, username, first_name, last_name, created_at, updated_at, deactivated_at
+		FROM employee
+		WHERE username = $1
+	`
+
+	var employee models.Employee
+	ctx, span := startSpan(ctx, "EmployeeRepository.GetEmployeeByUsername", query)
+	err := repo.DB.GetContext(ctx, &employee, query, username)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee")
+	}
+
+	return &employee, nil
+}
+
+// EditEmployee updates the non-empty names of an active employee and fills it with the stored values.
+func (repo *EmployeeRepository) EditEmployee(ctx context.Context, employee *models.Employee) error {
+	query := `
+		UPDATE employee
+		SET first_name = COALESCE(NULLIF($2, ''), first_name),
+			last_name = COALESCE(NULLIF($3, ''), last_name),
+			updated_at = $4
+		WHERE username = $1 AND deactivated_at IS NULL
+		RETURNING id, username, first_name, last_name, created_at, updated_at, deactivated_at
+	`
+
+	ctx, span := startSpan(ctx, "EmployeeRepository.EditEmployee", query)
+	err := repo.DB.GetContext(ctx, employee, query, employee.Username, employee.FirstName, employee.LastName, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to edit employee")
+	}
+
+	return nil
+}
+
+func (repo *EmployeeRepository) DeactivateEmployee(ctx context.Context, username string) error {
+	query := `
+		UPDATE employee
+		SET deactivated_at = $2, updated_at = $2
+		WHERE username = $1 AND deactivated_at IS NULL
+	`
+
+	ctx, span := startSpan(ctx, "EmployeeRepository.DeactivateEmployee", query)
+	result, err := repo.DB.ExecContext(ctx, query, username, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to deactivate employee")
+	}
+
+	deactivated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to deactivate employee")
+	}
+
+	if deactivated == 0 {
+		return errors.Wrap(sql.ErrNoRows, "failed to deactivate employee")
+	}
+
+	return nil
+}
+
+// SetPasswordReset stores the hash of a new reset token of an active employee, replacing the previous one.
+func (repo *EmployeeRepository) SetPasswordReset(ctx context.Context, username string, tokenHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE employee
+		SET password_reset_hash = $2, password_reset_expires_at = $3
+		WHERE username = $1 AND deactivated_at IS NULL
+	`
+
+	ctx, span := startSpan(ctx, "EmployeeRepository.SetPasswordReset", query)
+	result, err := repo.DB.ExecContext(ctx, query, username, tokenHash, expiresAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to set password reset")
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to set password reset")
+	}
+
+	if updated == 0 {
+		return errors.Wrap(sql.ErrNoRows, "failed to set password reset")
+	}
+
+	return nil
+}
+
+// CheckUserIsOwnerOf reports whether the first employee owns the organization the second one is responsible for.
+func (repo *EmployeeRepository) CheckUserIsOwnerOf(ctx context.Context, ownerUsername string, username string) (bool, error) {
+	query := `
+		SELECT COUNT(*) > 0
+		FROM organization_responsible org_res
+		JOIN employee e ON org_res.user_id = e.id
+		JOIN organization_responsible other_res ON other_res.organization_id = org_res.organization_id
+		JOIN employee other ON other_res.user_id = other.id
+		WHERE e.username = $1 AND e.deactivated_at IS NULL AND org_res.role = 'owner' AND other.username = $2
+	`
+
+	var isOwner bool
+	ctx, span := startSpan(ctx, "EmployeeRepository.CheckUserIsOwnerOf", query)
+	err := repo.DB.GetContext(ctx, &isOwner, query, ownerUsername, username)
+	endSpan(span, err)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check organization owner")
+	}
+
+	return isOwner, nil
+}
@@ -0,0 +1,19 @@
+package postgresql
+
+import (
+	"github.com/jackc/pgx"
+	"github.com/pkg/errors"
+)
+
+const uniqueViolationCode = "23505"
+
+func isUniqueViolation(err error) bool {
+	switch pgErr := errors.Cause(err).(type) {
+	case pgx.PgError:
+		return pgErr.Code == uniqueViolationCode
+	case *pgx.PgError:
+		return pgErr.Code == uniqueViolationCode
+	default:
+		return false
+	}
+}
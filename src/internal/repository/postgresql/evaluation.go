@@ -0,0 +1,258 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type EvaluationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewEvaluationRepository(db *sqlx.DB) _interface.EvaluationRepository {
+	return &EvaluationRepository{
+		DB: db,
+	}
+}
+
+func (repo *EvaluationRepository) GetCriteria(ctx context.Context, tenderID uuid.UUID) ([]models.EvaluationCriterion, error) {
+	return getCriteria(ctx, repo.DB, tenderID)
+}
+
+func getCriteria(ctx context.Context, db sqlx.QueryerContext, tenderID uuid.UUID) ([]models.EvaluationCriterion, error) {
+	query := `
+		SELECT id, tender_id, name, kind, weight, position, created_at
+		FROM tender_criterion
+		WHERE tender_id = $1
+		ORDER BY position
+	`
+
+	criteria := []models.EvaluationCriterion{}
+	ctx, span := startSpan(ctx, "getCriteria", query)
+	err := sqlx.SelectContext(ctx, db, &criteria, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get evaluation criteria")
+	}
+
+	return criteria, nil
+}
+
+// SetCriteria replaces the evaluation criteria of the tender. Criteria cannot be changed once
+// any bid has been scored by them.
+func (repo *EvaluationRepository) SetCriteria(ctx context.Context, tenderID uuid.UUID, criteria []models.EvaluationCriterion) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tender, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM proposal_score s
+			JOIN tender_criterion c ON s.criterion_id = c.id
+			WHERE c.tender_id = $1
+		)
+	`
+
+	var scored bool
+	spanCtx, span := startSpan(ctx, "EvaluationRepository.SetCriteria", query)
+	err = tx.GetContext(spanCtx, &scored, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to check proposal scores")
+	}
+
+	if scored {
+		return errors.Wrap(models.ErrInvalidState, "criteria cannot be changed after bids have been scored")
+	}
+
+	before, err := getCriteria(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	query = `DELETE FROM tender_criterion WHERE tender_id = $1`
+
+	spanCtx, span = startSpan(ctx, "EvaluationRepository.SetCriteria", query)
+	_, err = tx.ExecContext(spanCtx, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete evaluation criteria")
+	}
+
+	query = `
+		INSERT INTO tender_criterion (id, tender_id, name, kind, weight, position, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	now := time.Now()
+	for i := range criteria {
+		criteria[i].ID = uuid.New()
+		criteria[i].TenderID = tenderID
+		criteria[i].Position = i
+		criteria[i].CreatedAt = now
+
+		spanCtx, span = startSpan(ctx, "EvaluationRepository.SetCriteria", query)
+		_, err = tx.ExecContext(spanCtx, query, criteria[i].ID, tenderID, criteria[i].Name, string(criteria[i].Kind), criteria[i].Weight, i, now)
+		endSpan(span, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to create evaluation criterion")
+		}
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "set",
+		EntityType:     auditEntityTenderCriteria,
+		EntityID:       tenderID,
+		OrganizationID: tender.OrganizationID,
+		Before:         before,
+		After:          criteria,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// SetScores saves the responsible's scores of the proposal, replacing the earlier scores by the same criteria.
+func (repo *EvaluationRepository) SetScores(ctx context.Context, proposalID uuid.UUID, authorID uuid.UUID, scores []models.ProposalScore) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO proposal_score (proposal_id, criterion_id, author_id, score, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (proposal_id, criterion_id, author_id) DO UPDATE
+		SET score = EXCLUDED.score, updated_at = EXCLUDED.updated_at
+	`
+
+	now := time.Now()
+	for i := range scores {
+		scores[i].ProposalID = proposalID
+		scores[i].AuthorID = authorID
+		scores[i].UpdatedAt = now
+
+		spanCtx, span := startSpan(ctx, "EvaluationRepository.SetScores", query)
+		_, err = tx.ExecContext(spanCtx, query, proposalID, scores[i].CriterionID, authorID, scores[i].Score, now)
+		endSpan(span, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to save proposal score")
+		}
+	}
+
+	query = `
+		SELECT t.organization_id
+		FROM proposal p
+		JOIN tender t ON p.tender_id = t.id
+		WHERE p.id = $1
+	`
+
+	var organizationID uuid.UUID
+	spanCtx, span := startSpan(ctx, "EvaluationRepository.SetScores", query)
+	err = tx.GetContext(spanCtx, &organizationID, query, proposalID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tender organization")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "score",
+		EntityType:     auditEntityProposalScore,
+		EntityID:       proposalID,
+		OrganizationID: organizationID,
+		After:          scores,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *EvaluationRepository) GetScores(ctx context.Context, proposalID uuid.UUID) ([]models.ProposalScore, error) {
+	query := `
+		SELECT s.proposal_id, s.criterion_id, s.author_id, s.score, s.updated_at
+		FROM proposal_score s
+		JOIN tender_criterion c ON s.criterion_id = c.id
+		WHERE s.proposal_id = $1
+		ORDER BY c.position, s.updated_at
+	`
+
+	scores := []models.ProposalScore{}
+	ctx, span := startSpan(ctx, "EvaluationRepository.GetScores", query)
+	err := repo.DB.SelectContext(ctx, &scores, query, proposalID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get proposal scores")
+	}
+
+	return scores, nil
+}
+
+// GetRanking returns the published proposals of the tender ordered by their aggregated score;
+// ties go to the lower price.
+func (repo *EvaluationRepository) GetRanking(ctx context.Context, tenderID uuid.UUID) ([]models.RankingEntry, error) {
+	query := `
+		WITH criterion_score AS (
+			SELECT s.proposal_id, c.id AS criterion_id, c.weight, AVG(s.score) AS average
+			FROM proposal_score s
+			JOIN tender_criterion c ON s.criterion_id = c.id
+			WHERE c.tender_id = $1
+			GROUP BY s.proposal_id, c.id, c.weight
+		), total AS (
+			SELECT SUM(weight) AS weight
+			FROM tender_criterion
+			WHERE tender_id = $1
+		)
+		SELECT p.id AS proposal_id, p.title, p.organization_id, p.price, p.currency,
+			COALESCE(ROUND(SUM(cs.weight * cs.average) / NULLIF(total.weight, 0), 2), 0)::float8 AS score,
+			COUNT(cs.criterion_id) AS scored_criteria,
+			(SELECT COUNT(DISTINCT author_id) FROM proposal_score WHERE proposal_id = p.id) AS scorers
+		FROM proposal p
+		CROSS JOIN total
+		LEFT JOIN criterion_score cs ON cs.proposal_id = p.id
+		WHERE p.tender_id = $1 AND p.status = 'PUBLISHED'
+		GROUP BY p.id, total.weight
+		ORDER BY score DESC, p.price ASC NULLS LAST, p.created_at
+	`
+
+	ranking := []models.RankingEntry{}
+	ctx, span := startSpan(ctx, "EvaluationRepository.GetRanking", query)
+	err := repo.DB.SelectContext(ctx, &ranking, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get proposal ranking")
+	}
+
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+
+	return ranking, nil
+}
@@ -0,0 +1,133 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type IdempotencyRepository struct {
+	DB *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		DB: db,
+	}
+}
+
+// ReserveIdempotencyKey takes over an existing key only when it has expired or its request stopped
+// holding it without a response, so a key abandoned by a crashed request does not block retries forever.
+func (repo *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, reservation *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	query := `
+		INSERT INTO idempotency_key (owner, key, request_hash, reservation_id, created_at, locked_until, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (owner, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			reservation_id = EXCLUDED.reservation_id,
+			response_status = NULL,
+			response_content_type = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			completed_at = NULL,
+			locked_until = EXCLUDED.locked_until,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at < EXCLUDED.created_at
+			OR (idempotency_key.response_status IS NULL AND (idempotency_key.locked_until IS NULL OR idempotency_key.locked_until < EXCLUDED.created_at))
+	`
+
+	spanCtx, span := startSpan(ctx, "IdempotencyRepository.ReserveIdempotencyKey", query)
+	result, err := repo.DB.ExecContext(spanCtx, query, reservation.Owner, reservation.Key, reservation.RequestHash, reservation.ReservationID, reservation.CreatedAt, reservation.LockedUntil, reservation.ExpiresAt)
+	endSpan(span, err)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to reserve idempotency key")
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to reserve idempotency key")
+	}
+
+	if inserted > 0 {
+		return nil, true, nil
+	}
+
+	query = `
+		SELECT owner, key, request_hash, reservation_id, response_status, response_content_type, response_body, created_at, completed_at, locked_until, expires_at
+		FROM idempotency_key
+		WHERE owner = $1 AND key = $2
+	`
+
+	var existing models.IdempotencyKey
+	spanCtx, span = startSpan(ctx, "IdempotencyRepository.ReserveIdempotencyKey", query)
+	err = repo.DB.GetContext(spanCtx, &existing, query, reservation.Owner, reservation.Key)
+	endSpan(span, err)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get idempotency key")
+	}
+
+	return &existing, false, nil
+}
+
+func (repo *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, reservation *models.IdempotencyKey, status int, contentType string, body []byte) error {
+	query := `
+		UPDATE idempotency_key
+		SET response_status = $4, response_content_type = $5, response_body = $6, completed_at = $7, locked_until = NULL
+		WHERE owner = $1 AND key = $2 AND reservation_id = $3
+	`
+
+	ctx, span := startSpan(ctx, "IdempotencyRepository.CompleteIdempotencyKey", query)
+	_, err := repo.DB.ExecContext(ctx, query, reservation.Owner, reservation.Key, reservation.ReservationID, status, contentType, body, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to complete idempotency key")
+	}
+
+	return nil
+}
+
+func (repo *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, reservation *models.IdempotencyKey) error {
+	query := `
+		DELETE FROM idempotency_key
+		WHERE owner = $1 AND key = $2 AND reservation_id = $3 AND response_status IS NULL
+	`
+
+	ctx, span := startSpan(ctx, "IdempotencyRepository.ReleaseIdempotencyKey", query)
+	_, err := repo.DB.ExecContext(ctx, query, reservation.Owner, reservation.Key, reservation.ReservationID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to release idempotency key")
+	}
+
+	return nil
+}
+
+func (repo *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM idempotency_key
+		WHERE ctid IN (
+			SELECT ctid
+			FROM idempotency_key
+			WHERE expires_at < $1
+			LIMIT $2
+		)
+	`
+
+	ctx, span := startSpan(ctx, "IdempotencyRepository.DeleteExpiredIdempotencyKeys", query)
+	result, err := repo.DB.ExecContext(ctx, query, now, limit)
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete expired idempotency keys")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete expired idempotency keys")
+	}
+
+	return int(deleted), nil
+}
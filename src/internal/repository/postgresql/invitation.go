@@ -0,0 +1,159 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type InvitationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewInvitationRepository(db *sqlx.DB) _interface.InvitationRepository {
+	return &InvitationRepository{
+		DB: db,
+	}
+}
+
+// CreateInvitation invites an active employee or an organization that is not deleted. Only one
+// active invitation per invitee is allowed; revoked invitations don't count.
+func (repo *InvitationRepository) CreateInvitation(ctx context.Context, tenderID uuid.UUID, request models.InviteRequest, invitedByUsername string) (*models.TenderInvitation, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tender, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var query string
+	var invitee interface{}
+	if request.Username != "" {
+		query = `
+			INSERT INTO tender_invitation (id, tender_id, employee_id, invited_by, created_at)
+			SELECT $1, $2, e.id, inviter.id, $4
+			FROM employee e, employee inviter
+			WHERE e.username = $3 AND e.deactivated_at IS NULL AND inviter.username = $5
+			RETURNING id, tender_id, organization_id, employee_id, $3 AS username, invited_by, created_at, revoked_at
+		`
+		invitee = request.Username
+	} else {
+		query = `
+			INSERT INTO tender_invitation (id, tender_id, organization_id, invited_by, created_at)
+			SELECT $1, $2, o.id, inviter.id, $4
+			FROM organization o, employee inviter
+			WHERE o.id = $3 AND o.deleted_at IS NULL AND inviter.username = $5
+			RETURNING id, tender_id, organization_id, employee_id, NULL AS username, invited_by, created_at, revoked_at
+		`
+		invitee = *request.OrganizationID
+	}
+
+	var invitation models.TenderInvitation
+	spanCtx, span := startSpan(ctx, "InvitationRepository.CreateInvitation", query)
+	err = tx.GetContext(spanCtx, &invitation, query, uuid.New(), tenderID, invitee, time.Now(), invitedByUsername)
+	endSpan(span, err)
+	if isUniqueViolation(err) {
+		return nil, errors.Wrap(models.ErrAlreadyExists, "invitee is already invited to the tender")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tender invitation")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityTenderInvitation,
+		EntityID:       invitation.ID,
+		OrganizationID: tender.OrganizationID,
+		After:          invitation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &invitation, nil
+}
+
+func (repo *InvitationRepository) GetInvitations(ctx context.Context, tenderID uuid.UUID) ([]models.TenderInvitation, error) {
+	query := `
+		SELECT i.id, i.tender_id, i.organization_id, i.employee_id, e.username, i.invited_by, i.created_at, i.revoked_at
+		FROM tender_invitation i
+		LEFT JOIN employee e ON i.employee_id = e.id
+		WHERE i.tender_id = $1
+		ORDER BY i.created_at
+	`
+
+	invitations := []models.TenderInvitation{}
+	ctx, span := startSpan(ctx, "InvitationRepository.GetInvitations", query)
+	err := repo.DB.SelectContext(ctx, &invitations, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tender invitations")
+	}
+
+	return invitations, nil
+}
+
+// RevokeInvitation revokes an active invitation. The invitation is kept for the history.
+func (repo *InvitationRepository) RevokeInvitation(ctx context.Context, tenderID uuid.UUID, invitationID uuid.UUID) (*models.TenderInvitation, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tender, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE tender_invitation i
+		SET revoked_at = $3
+		WHERE i.id = $1 AND i.tender_id = $2 AND i.revoked_at IS NULL
+		RETURNING i.id, i.tender_id, i.organization_id, i.employee_id, (SELECT username FROM employee WHERE id = i.employee_id) AS username,
+			i.invited_by, i.created_at, i.revoked_at
+	`
+
+	var invitation models.TenderInvitation
+	spanCtx, span := startSpan(ctx, "InvitationRepository.RevokeInvitation", query)
+	err = tx.GetContext(spanCtx, &invitation, query, invitationID, tenderID, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to revoke tender invitation")
+	}
+
+	before := invitation
+	before.RevokedAt = nil
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "revoke",
+		EntityType:     auditEntityTenderInvitation,
+		EntityID:       invitation.ID,
+		OrganizationID: tender.OrganizationID,
+		Before:         before,
+		After:          invitation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &invitation, nil
+}
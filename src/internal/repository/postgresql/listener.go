@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx"
+	"github.com/pkg/errors"
+)
+
+const listenerReconnectDelay = time.Second
+
+// Listener receives notifications of a PostgreSQL channel on a dedicated connection,
+// so it does not take a connection from the pool for the lifetime of the service.
+type Listener struct {
+	Config  pgx.ConnConfig
+	Channel string
+}
+
+func NewListener(dsn string, channel string) (*Listener, error) {
+	config, err := pgx.ParseDSN(dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse listener DSN")
+	}
+
+	return &Listener{Config: config, Channel: channel}, nil
+}
+
+// Run passes notification payloads to handle until the context is canceled, reconnecting when
+// the connection is lost. Notifications sent while disconnected are lost.
+func (l *Listener) Run(ctx context.Context, handle func(payload string)) {
+	for ctx.Err() == nil {
+		if err := l.listen(ctx, handle); err != nil && ctx.Err() == nil {
+			log.Println("Error with listening to notifications.", err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(listenerReconnectDelay):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context, handle func(payload string)) error {
+	conn, err := pgx.Connect(l.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect listener")
+	}
+	defer conn.Close()
+
+	if err = conn.Listen(l.Channel); err != nil {
+		return errors.Wrap(err, "failed to listen to channel")
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to wait for notification")
+		}
+
+		handle(notification.Payload)
+	}
+}
@@ -0,0 +1,179 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type LotRepository struct {
+	DB *sqlx.DB
+}
+
+func NewLotRepository(db *sqlx.DB) _interface.LotRepository {
+	return &LotRepository{
+		DB: db,
+	}
+}
+
+func (repo *LotRepository) GetLots(ctx context.Context, tenderID uuid.UUID) ([]models.TenderLot, error) {
+	return getLots(ctx, repo.DB, tenderID)
+}
+
+func getLots(ctx context.Context, db sqlx.QueryerContext, tenderID uuid.UUID) ([]models.TenderLot, error) {
+	query := `
+		SELECT id, tender_id, title, COALESCE(description, '') AS description, service_type, budget_min, budget_max, position, status, awarded_proposal_id, awarded_at, created_at
+		FROM tender_lot
+		WHERE tender_id = $1
+		ORDER BY position
+	`
+
+	lots := []models.TenderLot{}
+	ctx, span := startSpan(ctx, "getLots", query)
+	err := sqlx.SelectContext(ctx, db, &lots, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tender lots")
+	}
+
+	return lots, nil
+}
+
+// SetLots replaces the lots of the tender. Lots cannot be changed once bids have been submitted,
+// since the bids refer to them.
+func (repo *LotRepository) SetLots(ctx context.Context, tenderID uuid.UUID, lots []models.TenderLot) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tender, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM proposal WHERE tender_id = $1)`
+
+	var submitted bool
+	spanCtx, span := startSpan(ctx, "LotRepository.SetLots", query)
+	err = tx.GetContext(spanCtx, &submitted, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to check tender proposals")
+	}
+
+	if submitted {
+		return errors.Wrap(models.ErrInvalidState, "lots cannot be changed after bids have been submitted")
+	}
+
+	before, err := getLots(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	query = `DELETE FROM tender_lot WHERE tender_id = $1`
+
+	spanCtx, span = startSpan(ctx, "LotRepository.SetLots", query)
+	_, err = tx.ExecContext(spanCtx, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete tender lots")
+	}
+
+	query = `
+		INSERT INTO tender_lot (id, tender_id, title, description, service_type, budget_min, budget_max, position, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	now := time.Now()
+	for i := range lots {
+		lots[i].ID = uuid.New()
+		lots[i].TenderID = tenderID
+		lots[i].Position = i
+		lots[i].Status = models.LotOpen
+		lots[i].AwardedProposalID = nil
+		lots[i].AwardedAt = nil
+		lots[i].CreatedAt = now
+
+		spanCtx, span = startSpan(ctx, "LotRepository.SetLots", query)
+		_, err = tx.ExecContext(spanCtx, query, lots[i].ID, tenderID, lots[i].Title, lots[i].Description, lots[i].ServiceType,
+			lots[i].BudgetMin, lots[i].BudgetMax, i, string(lots[i].Status), now)
+		endSpan(span, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to create tender lot")
+		}
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "set",
+		EntityType:     auditEntityTenderLots,
+		EntityID:       tenderID,
+		OrganizationID: tender.OrganizationID,
+		Before:         before,
+		After:          lots,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// awardLots awards the open lots the proposal is for to it and reports whether every lot of the
+// tender has been awarded.
+func awardLots(ctx context.Context, tx *sqlx.Tx, tender *models.Tender, lots []models.TenderLot, proposal models.Proposal, now time.Time) (bool, error) {
+	query := `
+		UPDATE tender_lot
+		SET status = $2, awarded_proposal_id = $3, awarded_at = $4
+		WHERE id = $1
+	`
+
+	targeted := make(map[uuid.UUID]bool, len(proposal.LotIDs))
+	for _, lotID := range proposal.LotIDs {
+		targeted[lotID] = true
+	}
+
+	allAwarded := true
+	for _, lot := range lots {
+		if !targeted[lot.ID] || lot.Status != models.LotOpen {
+			allAwarded = allAwarded && lot.Status == models.LotAwarded
+			continue
+		}
+
+		spanCtx, span := startSpan(ctx, "awardLots", query)
+		_, err := tx.ExecContext(spanCtx, query, lot.ID, string(models.LotAwarded), proposal.ID, now)
+		endSpan(span, err)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to award tender lot")
+		}
+
+		awarded := lot
+		awarded.Status = models.LotAwarded
+		awarded.AwardedProposalID = &proposal.ID
+		awarded.AwardedAt = &now
+		err = recordChange(ctx, tx, auditChange{
+			Action:         "award",
+			EntityType:     auditEntityTenderLot,
+			EntityID:       lot.ID,
+			OrganizationID: tender.OrganizationID,
+			Before:         lot,
+			After:          awarded,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return allAwarded, nil
+}
@@ -0,0 +1,133 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type MessageRepository struct {
+	DB *sqlx.DB
+}
+
+func NewMessageRepository(db *sqlx.DB) _interface.MessageRepository {
+	return &MessageRepository{
+		DB: db,
+	}
+}
+
+func (repo *MessageRepository) CreateMessage(ctx context.Context, message *models.ProposalMessage) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO proposal_message (id, proposal_id, organization_id, sender_side, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	message.ID = uuid.New()
+	message.CreatedAt = time.Now()
+
+	spanCtx, span := startSpan(ctx, "MessageRepository.CreateMessage", query)
+	_, err = tx.ExecContext(spanCtx, query, message.ID, message.ProposalID, message.OrganizationID, string(message.SenderSide), message.AuthorID, message.Body, message.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to create message")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityProposalMessage,
+		EntityID:       message.ID,
+		OrganizationID: message.OrganizationID,
+		After:          message,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *MessageRepository) GetMessages(ctx context.Context, proposalID uuid.UUID, limit, offset int) ([]models.ProposalMessage, error) {
+	query := `
+		SELECT id, proposal_id, organization_id, sender_side, author_id, body, created_at, read_at, read_by
+		FROM proposal_message
+		WHERE proposal_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	messages := []models.ProposalMessage{}
+	ctx, span := startSpan(ctx, "MessageRepository.GetMessages", query)
+	err := repo.DB.SelectContext(ctx, &messages, query, proposalID, limit, offset)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get messages")
+	}
+
+	return messages, nil
+}
+
+// MarkRead keeps the first read receipt of a message: messages already read by another responsible of
+// the side are left as they are.
+func (repo *MessageRepository) MarkRead(ctx context.Context, proposalID uuid.UUID, messageID uuid.UUID, side models.MessageSide, readerID uuid.UUID) (*models.ProposalMessage, error) {
+	message, err := repo.getMessage(ctx, proposalID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.SenderSide == side {
+		return nil, errors.Wrap(models.ErrInvalidState, "own messages cannot be marked read")
+	}
+
+	if message.ReadAt != nil {
+		return message, nil
+	}
+
+	query := `
+		UPDATE proposal_message
+		SET read_at = $5, read_by = $6
+		WHERE proposal_id = $1 AND sender_side = $2 AND read_at IS NULL AND (created_at, id) <= ($3::timestamp, $4::uuid)
+	`
+
+	spanCtx, span := startSpan(ctx, "MessageRepository.MarkRead", query)
+	_, err = repo.DB.ExecContext(spanCtx, query, proposalID, string(message.SenderSide), message.CreatedAt, message.ID, time.Now(), readerID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to mark messages as read")
+	}
+
+	return repo.getMessage(ctx, proposalID, messageID)
+}
+
+func (repo *MessageRepository) getMessage(ctx context.Context, proposalID uuid.UUID, messageID uuid.UUID) (*models.ProposalMessage, error) {
+	query := `
+		SELECT id, proposal_id, organization_id, sender_side, author_id, body, created_at, read_at, read_by
+		FROM proposal_message
+		WHERE id = $1 AND proposal_id = $2
+	`
+
+	var message models.ProposalMessage
+	ctx, span := startSpan(ctx, "MessageRepository.getMessage", query)
+	err := repo.DB.GetContext(ctx, &message, query, messageID, proposalID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get message")
+	}
+
+	return &message, nil
+}
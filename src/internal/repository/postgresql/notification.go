@@ -0,0 +1,213 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type NotificationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) _interface.NotificationRepository {
+	return &NotificationRepository{
+		DB: db,
+	}
+}
+
+// CreateForEvent notifies the tender's organization about published bids, the bid's author and
+// organization about decisions, feedback and amendments of the tender, bidders about answered
+// questions, the other side of the thread about messages, and both sides about the tender closure.
+func (repo *NotificationRepository) CreateForEvent(ctx context.Context, event models.DomainEvent) (int, error) {
+	if !event.Type.IsNotifiable() {
+		return 0, nil
+	}
+
+	tenderID, proposalID, err := eventTarget(ctx, repo.DB, event)
+	if err != nil {
+		return 0, err
+	}
+
+	notifyTender := event.Type == models.EventBidPublished || event.Type == models.EventTenderClosed
+	notifyBidders := event.Type != models.EventBidPublished
+	if event.Type == models.EventMessageSent {
+		var message models.ProposalMessage
+		if err := json.Unmarshal(event.Payload, &message); err != nil {
+			return 0, errors.Wrap(err, "failed to decode message event")
+		}
+		notifyTender = message.SenderSide == models.SideBidder
+		notifyBidders = !notifyTender
+	}
+
+	query := `
+		INSERT INTO notification (id, employee_id, event_id, event_type, payload, created_at)
+		SELECT uuid_generate_v4(), recipient.user_id, $1, $2::text, $3, $8
+		FROM (
+			SELECT org_res.user_id
+			FROM organization_responsible org_res
+			JOIN tender t ON t.organization_id = org_res.organization_id
+			WHERE t.id = $4 AND $6
+			UNION
+			SELECT org_res.user_id
+			FROM organization_responsible org_res
+			JOIN proposal p ON p.organization_id = org_res.organization_id
+			WHERE p.tender_id = $4 AND ($5::uuid IS NULL OR p.id = $5::uuid) AND $7
+			UNION
+			SELECT p.author_id
+			FROM proposal p
+			WHERE p.tender_id = $4 AND ($5::uuid IS NULL OR p.id = $5::uuid) AND $7
+		) recipient
+		JOIN employee e ON e.id = recipient.user_id
+		WHERE e.deactivated_at IS NULL AND NOT EXISTS (
+			SELECT 1
+			FROM notification_preference np
+			WHERE np.employee_id = recipient.user_id AND np.event_type = $2::text AND NOT np.enabled
+		)
+		ON CONFLICT (employee_id, event_id) DO NOTHING
+	`
+
+	ctx, span := startSpan(ctx, "NotificationRepository.CreateForEvent", query)
+	result, err := repo.DB.ExecContext(ctx, query, event.ID, string(event.Type), string(event.Payload), tenderID, proposalID,
+		notifyTender, notifyBidders, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create notifications")
+	}
+
+	created, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create notifications")
+	}
+
+	return int(created), nil
+}
+
+func (repo *NotificationRepository) GetNotifications(ctx context.Context, employeeID uuid.UUID, includeRead bool, limit int, offset int) ([]models.Notification, error) {
+	query := `
+		SELECT id, employee_id, event_id, event_type, payload::text AS payload, read_at, created_at
+		FROM notification
+		WHERE employee_id = $1 AND ($2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	notifications := []models.Notification{}
+	ctx, span := startSpan(ctx, "NotificationRepository.GetNotifications", query)
+	err := repo.DB.SelectContext(ctx, &notifications, query, employeeID, includeRead, limit, offset)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get notifications")
+	}
+
+	return notifications, nil
+}
+
+func (repo *NotificationRepository) MarkRead(ctx context.Context, employeeID uuid.UUID, notificationID uuid.UUID) (*models.Notification, error) {
+	query := `
+		UPDATE notification
+		SET read_at = COALESCE(read_at, $3)
+		WHERE employee_id = $1 AND id = $2
+		RETURNING id, employee_id, event_id, event_type, payload::text AS payload, read_at, created_at
+	`
+
+	var notification models.Notification
+	ctx, span := startSpan(ctx, "NotificationRepository.MarkRead", query)
+	err := repo.DB.GetContext(ctx, &notification, query, employeeID, notificationID, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to mark notification as read")
+	}
+
+	return &notification, nil
+}
+
+func (repo *NotificationRepository) MarkAllRead(ctx context.Context, employeeID uuid.UUID) (int, error) {
+	query := `
+		UPDATE notification
+		SET read_at = $2
+		WHERE employee_id = $1 AND read_at IS NULL
+	`
+
+	ctx, span := startSpan(ctx, "NotificationRepository.MarkAllRead", query)
+	result, err := repo.DB.ExecContext(ctx, query, employeeID, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to mark notifications as read")
+	}
+
+	marked, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to mark notifications as read")
+	}
+
+	return int(marked), nil
+}
+
+// GetPreferences returns the preference for every notifiable event; events without a stored preference are enabled.
+func (repo *NotificationRepository) GetPreferences(ctx context.Context, employeeID uuid.UUID) ([]models.NotificationPreference, error) {
+	query := `
+		SELECT event_type, enabled
+		FROM notification_preference
+		WHERE employee_id = $1
+	`
+
+	var stored []models.NotificationPreference
+	ctx, span := startSpan(ctx, "NotificationRepository.GetPreferences", query)
+	err := repo.DB.SelectContext(ctx, &stored, query, employeeID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get notification preferences")
+	}
+
+	enabled := make(map[models.EventType]bool, len(stored))
+	for _, preference := range stored {
+		enabled[preference.EventType] = preference.Enabled
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(models.NotificationEvents))
+	for _, eventType := range models.NotificationEvents {
+		value, ok := enabled[eventType]
+		preferences = append(preferences, models.NotificationPreference{EventType: eventType, Enabled: !ok || value})
+	}
+
+	return preferences, nil
+}
+
+func (repo *NotificationRepository) SetPreferences(ctx context.Context, employeeID uuid.UUID, preferences []models.NotificationPreference) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO notification_preference (employee_id, event_type, enabled, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (employee_id, event_type) DO UPDATE
+		SET enabled = EXCLUDED.enabled, updated_at = EXCLUDED.updated_at
+	`
+
+	now := time.Now()
+	for _, preference := range preferences {
+		spanCtx, span := startSpan(ctx, "NotificationRepository.SetPreferences", query)
+		_, err = tx.ExecContext(spanCtx, query, employeeID, string(preference.EventType), preference.Enabled, now)
+		endSpan(span, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to save notification preference")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
@@ -0,0 +1,400 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+type OrganizationRepository struct {
+	DB *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		DB: db,
+	}
+}
+
+func (repo *OrganizationRepository) GetResponsible(ctx context.Context, orgID uuid.UUID, username string) (*models.OrganizationResponsible, error) {
+	query := `
+		SELECT org_res.id, org_res.organization_id, org_res.user_id, e.username, org_res.role, org_res.granted_by, org_res.granted_at
+		FROM organization_responsible org_res
+		JOIN employee e ON org_res.user_id = e.id
+		JOIN organization o ON org_res.organization_id = o.id
+		WHERE org_res.organization_id = $1 AND e.username = $2 AND e.deactivated_at IS NULL AND o.deleted_at IS NULL
+	`
+
+	var responsible models.OrganizationResponsible
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetResponsible", query)
+	err := repo.DB.GetContext(ctx, &responsible, query, orgID, username)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organization responsible")
+	}
+
+	return &responsible, nil
+}
+
+func (repo *OrganizationRepository) GetResponsibleByUserID(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*models.OrganizationResponsible, error) {
+	query := `
+		SELECT org_res.id, org_res.organization_id, org_res.user_id, e.username, org_res.role, org_res.granted_by, org_res.granted_at
+		FROM organization_responsible org_res
+		JOIN employee e ON org_res.user_id = e.id
+		JOIN organization o ON org_res.organization_id = o.id
+		WHERE org_res.organization_id = $1 AND e.id = $2 AND e.deactivated_at IS NULL AND o.deleted_at IS NULL
+	`
+
+	var responsible models.OrganizationResponsible
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetResponsibleByUserID", query)
+	err := repo.DB.GetContext(ctx, &responsible, query, orgID, userID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organization responsible")
+	}
+
+	return &responsible, nil
+}
+
+// CreateOrganization creates the organization and makes the creator its first responsible,
+// so that somebody is allowed to manage it.
+func (repo *OrganizationRepository) CreateOrganization(ctx context.Context, organization *models.Organization, responsibleUsername string) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id
+		FROM employee
+		WHERE username = $1 AND deactivated_at IS NULL
+	`
+
+	var responsibleID uuid.UUID
+	spanCtx, span := startSpan(ctx, "OrganizationRepository.CreateOrganization", query)
+	err = tx.GetContext(spanCtx, &responsibleID, query, responsibleUsername)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to get responsible employee")
+	}
+
+	query = `
+		INSERT INTO organization (id, name, description, type, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	organization.ID = uuid.New()
+	organization.CreatedAt = time.Now()
+	organization.UpdatedAt = organization.CreatedAt
+	organization.DeletedAt = nil
+
+	spanCtx, span = startSpan(ctx, "OrganizationRepository.CreateOrganization", query)
+	_, err = tx.ExecContext(spanCtx, query, organization.ID, organization.Name, organization.Description, organization.Type, organization.CreatedAt, organization.UpdatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to create organization")
+	}
+
+	query = `
+		INSERT INTO organization_responsible (id, organization_id, user_id, role, granted_by, granted_at)
+		VALUES ($1, $2, $3, 'owner', $3, $4)
+	`
+
+	spanCtx, span = startSpan(ctx, "OrganizationRepository.CreateOrganization", query)
+	_, err = tx.ExecContext(spanCtx, query, uuid.New(), organization.ID, responsibleID, organization.CreatedAt)
+	endSpan(span, err)
+	if isUniqueViolation(err) {
+		return errors.Wrap(models.ErrAlreadyExists, "user is already responsible for an organization")
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to add organization responsible")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *OrganizationRepository) GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*models.Organization, error) {
+	query := `
+		SELECT id, name, description, type, created_at, updated_at, deleted_at
+		FROM organization
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	var organization models.Organization
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetOrganizationByID", query)
+	err := repo.DB.GetContext(ctx, &organization, query, orgID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organization")
+	}
+
+	return &organization, nil
+}
+
+func (repo *OrganizationRepository) GetOrganizations(ctx context.Context, limit int, offset int) ([]models.Organization, error) {
+	query := `
+		SELECT id, name, description, type, created_at, updated_at, deleted_at
+		FROM organization
+		WHERE deleted_at IS NULL
+		ORDER BY name
+		LIMIT $1 OFFSET $2
+	`
+
+	organizations := []models.Organization{}
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetOrganizations", query)
+	err := repo.DB.SelectContext(ctx, &organizations, query, limit, offset)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organizations")
+	}
+
+	return organizations, nil
+}
+
+// EditOrganization updates the non-empty fields and fills the organization with the stored values.
+func (repo *OrganizationRepository) EditOrganization(ctx context.Context, organization *models.Organization) error {
+	query := `
+		UPDATE organization
+		SET name = COALESCE(NULLIF($2, ''), name),
+			description = COALESCE(NULLIF($3, ''), description),
+			type = COALESCE(NULLIF($4, '')::organization_type, type),
+			updated_at = $5
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, name, description, type, created_at, updated_at, deleted_at
+	`
+
+	ctx, span := startSpan(ctx, "OrganizationRepository.EditOrganization", query)
+	err := repo.DB.GetContext(ctx, organization, query, organization.ID, organization.Name, organization.Description, string(organization.Type), time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to edit organization")
+	}
+
+	return nil
+}
+
+func (repo *OrganizationRepository) DeleteOrganization(ctx context.Context, orgID uuid.UUID) error {
+	query := `
+		UPDATE organization
+		SET deleted_at = $2, updated_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	ctx, span := startSpan(ctx, "OrganizationRepository.DeleteOrganization", query)
+	result, err := repo.DB.ExecContext(ctx, query, orgID, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete organization")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete organization")
+	}
+
+	if deleted == 0 {
+		return errors.Wrap(sql.ErrNoRows, "failed to delete organization")
+	}
+
+	return nil
+}
+
+func (repo *OrganizationRepository) GetResponsibles(ctx context.Context, orgID uuid.UUID) ([]models.OrganizationResponsible, error) {
+	query := `
+		SELECT org_res.id, org_res.organization_id, org_res.user_id, e.username, org_res.role, org_res.granted_by, org_res.granted_at
+		FROM organization_responsible org_res
+		JOIN employee e ON org_res.user_id = e.id
+		WHERE org_res.organization_id = $1
+		ORDER BY org_res.granted_at
+	`
+
+	responsibles := []models.OrganizationResponsible{}
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetResponsibles", query)
+	err := repo.DB.SelectContext(ctx, &responsibles, query, orgID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organization responsibles")
+	}
+
+	return responsibles, nil
+}
+
+func (repo *OrganizationRepository) AddResponsible(ctx context.Context, orgID uuid.UUID, username string, role models.ResponsibleRole, grantedByUsername string) (*models.OrganizationResponsible, error) {
+	query := `
+		INSERT INTO organization_responsible (id, organization_id, user_id, role, granted_by, granted_at)
+		SELECT $1, $2, e.id, $4, granter.id, $6
+		FROM employee e, employee granter
+		WHERE e.username = $3 AND e.deactivated_at IS NULL AND granter.username = $5
+		RETURNING id, organization_id, user_id, $3 AS username, role, granted_by, granted_at
+	`
+
+	var responsible models.OrganizationResponsible
+	ctx, span := startSpan(ctx, "OrganizationRepository.AddResponsible", query)
+	err := repo.DB.GetContext(ctx, &responsible, query, uuid.New(), orgID, username, string(role), grantedByUsername, time.Now())
+	endSpan(span, err)
+	if isUniqueViolation(err) {
+		return nil, errors.Wrap(models.ErrAlreadyExists, "user is already responsible for an organization")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add organization responsible")
+	}
+
+	return &responsible, nil
+}
+
+// ChangeResponsibleRole changes the role of a responsible, keeping at least one active owner.
+func (repo *OrganizationRepository) ChangeResponsibleRole(ctx context.Context, orgID uuid.UUID, username string, role models.ResponsibleRole) (*models.OrganizationResponsible, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	responsibles, err := repo.lockResponsibles(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkResponsibleChange(responsibles, username, role); err != nil {
+		return nil, errors.Wrap(err, "failed to change responsible role")
+	}
+
+	query := `
+		UPDATE organization_responsible org_res
+		SET role = $3
+		FROM employee e
+		WHERE org_res.user_id = e.id AND org_res.organization_id = $1 AND e.username = $2
+		RETURNING org_res.id, org_res.organization_id, org_res.user_id, e.username, org_res.role, org_res.granted_by, org_res.granted_at
+	`
+
+	var responsible models.OrganizationResponsible
+	spanCtx, span := startSpan(ctx, "OrganizationRepository.ChangeResponsibleRole", query)
+	err = tx.GetContext(spanCtx, &responsible, query, orgID, username, string(role))
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to change responsible role")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &responsible, nil
+}
+
+// RemoveResponsible removes the employee from the organization responsibles unless they are the last active
+// one or the last active owner. The responsibles are locked so that concurrent changes can't bypass the check.
+func (repo *OrganizationRepository) RemoveResponsible(ctx context.Context, orgID uuid.UUID, username string) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	responsibles, err := repo.lockResponsibles(ctx, tx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkResponsibleChange(responsibles, username, ""); err != nil {
+		return errors.Wrap(err, "failed to remove organization responsible")
+	}
+
+	query := `
+		DELETE FROM organization_responsible org_res
+		USING employee e
+		WHERE org_res.user_id = e.id AND org_res.organization_id = $1 AND e.username = $2
+	`
+
+	spanCtx, span := startSpan(ctx, "OrganizationRepository.RemoveResponsible", query)
+	_, err = tx.ExecContext(spanCtx, query, orgID, username)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove organization responsible")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// lockedResponsible is a responsible with the state of their employee account.
+type lockedResponsible struct {
+	models.OrganizationResponsible
+	Active bool `db:"active"`
+}
+
+func (repo *OrganizationRepository) lockResponsibles(ctx context.Context, tx *sqlx.Tx, orgID uuid.UUID) ([]lockedResponsible, error) {
+	query := `
+		SELECT org_res.id, org_res.organization_id, org_res.user_id, e.username, org_res.role, org_res.granted_by, org_res.granted_at,
+			e.deactivated_at IS NULL AS active
+		FROM organization_responsible org_res
+		JOIN employee e ON org_res.user_id = e.id
+		WHERE org_res.organization_id = $1
+		FOR UPDATE OF org_res
+	`
+
+	var responsibles []lockedResponsible
+	ctx, span := startSpan(ctx, "OrganizationRepository.lockResponsibles", query)
+	err := tx.SelectContext(ctx, &responsibles, query, orgID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lock organization responsibles")
+	}
+
+	return responsibles, nil
+}
+
+// checkResponsibleChange makes sure the employee is a responsible and that changing their role
+// to newRole (empty for removal) leaves the organization with an active responsible and an active owner.
+// Deactivated employees can't act for the organization, so they don't count.
+func checkResponsibleChange(responsibles []lockedResponsible, username string, newRole models.ResponsibleRole) error {
+	var target *lockedResponsible
+	otherActive, otherOwners := 0, 0
+
+	for i, responsible := range responsibles {
+		switch {
+		case responsible.Username == username:
+			target = &responsibles[i]
+		case !responsible.Active:
+		case responsible.Role == models.RoleOwner:
+			otherActive++
+			otherOwners++
+		default:
+			otherActive++
+		}
+	}
+
+	if target == nil {
+		return sql.ErrNoRows
+	}
+
+	if !target.Active {
+		return nil
+	}
+
+	if newRole == "" && otherActive == 0 {
+		return models.ErrLastResponsible
+	}
+
+	if target.Role == models.RoleOwner && otherOwners == 0 && newRole != models.RoleOwner {
+		return models.ErrLastOwner
+	}
+
+	return nil
+}
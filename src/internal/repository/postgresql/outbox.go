@@ -0,0 +1,331 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+	"avito_2024/src/internal/requestid"
+)
+
+type auditAction struct {
+	EntityType string
+	Action     string
+}
+
+// domainEvents maps audited changes to the domain events they emit.
+var domainEvents = map[auditAction]models.EventType{
+	{auditEntityTender, "publish"}:   models.EventTenderPublished,
+	{auditEntityTender, "close"}:     models.EventTenderClosed,
+	{auditEntityTender, "open_bids"}: models.EventBidsOpened,
+	{auditEntityProposal, "create"}:  models.EventBidCreated,
+	{auditEntityProposal, "publish"}: models.EventBidPublished,
+	{auditEntityProposal, "cancel"}:  models.EventBidCanceled,
+	{auditEntityProposal, "agree"}:   models.EventBidApproved,
+	{auditEntityProposal, "decline"}: models.EventBidRejected,
+	{auditEntityProposal, "stale"}:   models.EventBidStale,
+
+	{auditEntityProposalFeedback, "create"}: models.EventFeedbackAdded,
+	{auditEntityAuctionBid, "create"}:       models.EventAuctionBid,
+	{auditEntityTenderLot, "award"}:         models.EventLotAwarded,
+	{auditEntityTenderQuestion, "answer"}:   models.EventQuestionAnswered,
+	{auditEntityProposalMessage, "create"}:  models.EventMessageSent,
+}
+
+// recordChange writes the change to the audit log and, if the change emits a domain event,
+// to the outbox in the same transaction.
+func recordChange(ctx context.Context, tx *sqlx.Tx, change auditChange) error {
+	if err := writeAuditEvent(ctx, tx, change); err != nil {
+		return err
+	}
+
+	eventType, ok := domainEvents[auditAction{change.EntityType, change.Action}]
+	if !ok {
+		return nil
+	}
+
+	return writeOutboxEvent(ctx, tx, eventType, change)
+}
+
+func writeOutboxEvent(ctx context.Context, tx *sqlx.Tx, eventType models.EventType, change auditChange) error {
+	payload, err := marshalAuditState(change.After)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO outbox_event (id, event_type, aggregate_type, aggregate_id, organization_id, payload, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	spanCtx, span := startSpan(ctx, "writeOutboxEvent", query)
+	_, err = tx.ExecContext(spanCtx, query, uuid.New(), string(eventType), change.EntityType, change.EntityID,
+		change.OrganizationID, payload, nullString(requestid.FromContext(ctx)), time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to write outbox event")
+	}
+
+	return nil
+}
+
+type OutboxRepository struct {
+	DB *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) _interface.OutboxRepository {
+	return &OutboxRepository{
+		DB: db,
+	}
+}
+
+// pendingEvent is an outbox event with its delivery state.
+type pendingEvent struct {
+	models.DomainEvent
+	Attempts int `db:"attempts"`
+}
+
+// ProcessPending locks the due events with SKIP LOCKED, so several dispatchers can run at once
+// without publishing the same event twice. An event is not due while an earlier event of its
+// aggregate waits for a retry.
+func (repo *OutboxRepository) ProcessPending(ctx context.Context, limit int, publish func(context.Context, models.DomainEvent) error, retryAt func(failedAttempts int) (time.Time, bool)) (int, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT e.id, e.event_type, e.aggregate_type, e.aggregate_id, e.organization_id, e.payload::text AS payload, e.request_id, e.created_at, e.attempts
+		FROM outbox_event e
+		WHERE e.published_at IS NULL AND e.dead_at IS NULL AND (e.next_attempt_at IS NULL OR e.next_attempt_at <= $2)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM outbox_event earlier
+				WHERE earlier.aggregate_id = e.aggregate_id AND earlier.published_at IS NULL AND earlier.dead_at IS NULL
+					AND earlier.next_attempt_at > $2 AND (earlier.created_at, earlier.id) < (e.created_at, e.id)
+			)
+		ORDER BY e.created_at, e.id
+		LIMIT $1
+		FOR UPDATE OF e SKIP LOCKED
+	`
+
+	var events []pendingEvent
+	spanCtx, span := startSpan(ctx, "OutboxRepository.ProcessPending", query)
+	err = tx.SelectContext(spanCtx, &events, query, limit, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get pending events")
+	}
+
+	published := 0
+	failedAggregates := make(map[uuid.UUID]struct{})
+	for _, event := range events {
+		if _, failed := failedAggregates[event.AggregateID]; failed {
+			continue
+		}
+
+		if publishErr := publish(ctx, event.DomainEvent); publishErr != nil {
+			failedAggregates[event.AggregateID] = struct{}{}
+			if err = repo.recordFailure(ctx, tx, event, publishErr, retryAt); err != nil {
+				return published, err
+			}
+
+			continue
+		}
+
+		query = `
+			UPDATE outbox_event
+			SET published_at = $2, attempts = attempts + 1, next_attempt_at = NULL, last_error = NULL
+			WHERE id = $1
+		`
+
+		spanCtx, span = startSpan(ctx, "OutboxRepository.ProcessPending", query)
+		_, err = tx.ExecContext(spanCtx, query, event.ID, time.Now())
+		endSpan(span, err)
+		if err != nil {
+			return published, errors.Wrap(err, "failed to mark event as published")
+		}
+
+		published++
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return published, nil
+}
+
+func (repo *OutboxRepository) recordFailure(ctx context.Context, tx *sqlx.Tx, event pendingEvent, publishErr error, retryAt func(failedAttempts int) (time.Time, bool)) error {
+	var nextAttemptAt, deadAt *time.Time
+	if next, retry := retryAt(event.Attempts + 1); retry {
+		nextAttemptAt = &next
+	} else {
+		now := time.Now()
+		deadAt = &now
+	}
+
+	query := `
+		UPDATE outbox_event
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3, dead_at = $4
+		WHERE id = $1
+	`
+
+	ctx, span := startSpan(ctx, "OutboxRepository.ProcessPending", query)
+	_, err := tx.ExecContext(ctx, query, event.ID, publishErr.Error(), nextAttemptAt, deadAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to record publish failure")
+	}
+
+	return nil
+}
+
+func (repo *OutboxRepository) GetEvent(ctx context.Context, eventID uuid.UUID) (*models.DomainEvent, error) {
+	query := `
+		SELECT id, event_type, aggregate_type, aggregate_id, organization_id, payload::text AS payload, request_id, created_at
+		FROM outbox_event
+		WHERE id = $1
+	`
+
+	var event models.DomainEvent
+	ctx, span := startSpan(ctx, "OutboxRepository.GetEvent", query)
+	err := repo.DB.GetContext(ctx, &event, query, eventID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get event")
+	}
+
+	return &event, nil
+}
+
+// GetEventAudience returns the employees allowed to see the event: responsibles of the tender's
+// organization and of the bidding organizations, and the bid authors. Tender events concern every
+// bid on the tender, proposal events only the proposal itself. Created bids are drafts, so their
+// events are hidden from the tender's organization. Tender publications and answered questions are
+// public on public tenders; on private tenders they are shown to the tender's organization, the
+// bidders and the invitees.
+func (repo *OutboxRepository) GetEventAudience(ctx context.Context, event models.DomainEvent) (bool, []uuid.UUID, error) {
+	tenderID, proposalID, err := eventTarget(ctx, repo.DB, event)
+	if err != nil {
+		return false, nil, err
+	}
+
+	announced := event.Type == models.EventTenderPublished || event.Type == models.EventQuestionAnswered
+	if announced {
+		query := `
+			SELECT visibility
+			FROM tender
+			WHERE id = $1
+		`
+
+		var visibility models.TenderVisibility
+		spanCtx, span := startSpan(ctx, "OutboxRepository.GetEventAudience", query)
+		err = repo.DB.GetContext(spanCtx, &visibility, query, tenderID)
+		endSpan(span, err)
+		if err != nil {
+			return false, nil, errors.Wrap(err, "failed to get tender visibility")
+		}
+
+		if visibility == models.VisibilityPublic {
+			return true, nil, nil
+		}
+	}
+
+	query := `
+		SELECT org_res.user_id
+		FROM organization_responsible org_res
+		WHERE org_res.organization_id IN (
+			SELECT t.organization_id FROM tender t WHERE t.id = $1 AND $3
+			UNION
+			SELECT p.organization_id FROM proposal p WHERE p.tender_id = $1 AND ($2::uuid IS NULL OR p.id = $2::uuid)
+			UNION
+			SELECT i.organization_id FROM tender_invitation i WHERE i.tender_id = $1 AND i.revoked_at IS NULL AND $4
+		)
+		UNION
+		SELECT p.author_id
+		FROM proposal p
+		WHERE p.tender_id = $1 AND ($2::uuid IS NULL OR p.id = $2::uuid)
+		UNION
+		SELECT i.employee_id
+		FROM tender_invitation i
+		WHERE i.tender_id = $1 AND i.revoked_at IS NULL AND i.employee_id IS NOT NULL AND $4
+	`
+
+	var userIDs []uuid.UUID
+	ctx, span := startSpan(ctx, "OutboxRepository.GetEventAudience", query)
+	err = repo.DB.SelectContext(ctx, &userIDs, query, tenderID, proposalID, event.Type != models.EventBidCreated, announced)
+	endSpan(span, err)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to get event audience")
+	}
+
+	return false, userIDs, nil
+}
+
+// eventTarget finds the tender the event relates to and, for proposal events, the proposal.
+func eventTarget(ctx context.Context, db sqlx.QueryerContext, event models.DomainEvent) (uuid.UUID, *uuid.UUID, error) {
+	var proposalID uuid.UUID
+	switch event.AggregateType {
+	case auditEntityTender:
+		return event.AggregateID, nil, nil
+	case auditEntityProposal:
+		proposalID = event.AggregateID
+	case auditEntityProposalFeedback:
+		var feedback models.ProposalFeedback
+		if err := json.Unmarshal(event.Payload, &feedback); err != nil {
+			return uuid.Nil, nil, errors.Wrap(err, "failed to decode feedback event")
+		}
+		proposalID = feedback.ProposalID
+	case auditEntityProposalMessage:
+		var message models.ProposalMessage
+		if err := json.Unmarshal(event.Payload, &message); err != nil {
+			return uuid.Nil, nil, errors.Wrap(err, "failed to decode message event")
+		}
+		proposalID = message.ProposalID
+	case auditEntityAuctionBid:
+		// Auction bids concern every bidder on the tender.
+		var bid models.AuctionBid
+		if err := json.Unmarshal(event.Payload, &bid); err != nil {
+			return uuid.Nil, nil, errors.Wrap(err, "failed to decode auction bid event")
+		}
+		return bid.TenderID, nil, nil
+	case auditEntityTenderLot:
+		var lot models.TenderLot
+		if err := json.Unmarshal(event.Payload, &lot); err != nil {
+			return uuid.Nil, nil, errors.Wrap(err, "failed to decode lot event")
+		}
+		return lot.TenderID, nil, nil
+	case auditEntityTenderQuestion:
+		var question models.TenderQuestion
+		if err := json.Unmarshal(event.Payload, &question); err != nil {
+			return uuid.Nil, nil, errors.Wrap(err, "failed to decode question event")
+		}
+		return question.TenderID, nil, nil
+	default:
+		return uuid.Nil, nil, errors.Errorf("unknown aggregate type %q", event.AggregateType)
+	}
+
+	query := `
+		SELECT tender_id
+		FROM proposal
+		WHERE id = $1
+	`
+
+	var tenderID uuid.UUID
+	ctx, span := startSpan(ctx, "eventTarget", query)
+	err := sqlx.GetContext(ctx, db, &tenderID, query, proposalID)
+	endSpan(span, err)
+	if err != nil {
+		return uuid.Nil, nil, errors.Wrap(err, "failed to get proposal tender")
+	}
+
+	return tenderID, &proposalID, nil
+}
@@ -1,6 +1,9 @@
 package postgresql
 
 import (
+	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"avito_2024/src/internal/domain/interface"
@@ -20,26 +23,19 @@ func NewProposalRepository(db *sqlx.DB) _interface.ProposalRepository {
 	}
 }
 
-func (repo *ProposalRepository) CheckUserBelongsToOrganizationByID(orgID uuid.UUID, userID uuid.UUID) (bool, error) {
-	query := `
-		SELECT COUNT(*) > 0
-		FROM organization_responsible org_res
-		WHERE org_res.organization_id = $1 AND org_res.user_id = $2
-	`
-
-	var exists bool
-	err := repo.DB.Get(&exists, query, orgID, userID)
+func (repo *ProposalRepository) CreateProposal(ctx context.Context, proposal *models.Proposal) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to check user organization membership")
+		return errors.Wrap(err, "failed to begin transaction")
 	}
+	defer tx.Rollback()
 
-	return exists, nil
-}
-
-func (repo *ProposalRepository) CreateProposal(proposal *models.Proposal) error {
 	query := `
-		INSERT INTO proposal (id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO proposal (id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, lot_ids, tender_version)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, COALESCE(string_to_array($14, ',')::uuid[], '{}'), t.version
+		FROM tender t
+		WHERE t.id = $4
+		RETURNING tender_version
 	`
 
 	proposal.ID = uuid.New()
@@ -48,22 +44,41 @@ func (repo *ProposalRepository) CreateProposal(proposal *models.Proposal) error
 	proposal.CreatedAt = time.Now()
 	proposal.UpdatedAt = time.Now()
 
-	_, err := repo.DB.Exec(query, proposal.ID, proposal.Title, proposal.Description, proposal.TenderID, proposal.OrganizationID, proposal.AuthorID, proposal.Status, proposal.Version, proposal.CreatedAt, proposal.UpdatedAt)
+	spanCtx, span := startSpan(ctx, "ProposalRepository.CreateProposal", query)
+	err = tx.GetContext(spanCtx, &proposal.TenderVersion, query, proposal.ID, proposal.Title, proposal.Description, proposal.TenderID, proposal.OrganizationID, proposal.AuthorID, proposal.Status, proposal.Version, proposal.CreatedAt, proposal.UpdatedAt, proposal.Price, proposal.Currency, proposal.SealedContent, proposal.LotIDs)
+	endSpan(span, err)
 	if err != nil {
 		return errors.Wrap(err, "failed to create proposal")
 	}
 
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityProposal,
+		EntityID:       proposal.ID,
+		OrganizationID: proposal.OrganizationID,
+		After:          proposal,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
 	return nil
 }
 
-func (repo *ProposalRepository) PublishProposal(proposalID uuid.UUID) error {
+func (repo *ProposalRepository) PublishProposal(ctx context.Context, proposalID uuid.UUID) error {
 	query := `
 		UPDATE proposal
 		SET status = 'PUBLISHED', updated_at = $2
 		WHERE id = $1
+		RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 	`
 
-	_, err := repo.DB.Exec(query, proposalID, time.Now())
+	_, err := repo.updateProposal(ctx, "ProposalRepository.PublishProposal", "publish", proposalID, query, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to publish proposal")
 	}
@@ -71,14 +86,16 @@ func (repo *ProposalRepository) PublishProposal(proposalID uuid.UUID) error {
 	return nil
 }
 
-func (repo *ProposalRepository) CancelProposal(proposalID uuid.UUID) error {
+func (repo *ProposalRepository) CancelProposal(ctx context.Context, proposalID uuid.UUID) error {
 	query := `
 		UPDATE proposal
 		SET status = 'CANCELED', updated_at = $2
 		WHERE id = $1
+		RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 	`
 
-	_, err := repo.DB.Exec(query, proposalID, time.Now())
+	_, err := repo.updateProposal(ctx, "ProposalRepository.CancelProposal", "cancel", proposalID, query, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to cancel proposal")
 	}
@@ -86,29 +103,58 @@ func (repo *ProposalRepository) CancelProposal(proposalID uuid.UUID) error {
 	return nil
 }
 
-func (repo *ProposalRepository) EditProposal(proposal *models.Proposal) error {
+// EditProposal updates the proposal and fills it with the stored values. The price and lots are only changed
+// when set; the sealed content is always replaced. An edit revises the proposal against the current version
+// of the tender.
+func (repo *ProposalRepository) EditProposal(ctx context.Context, proposal *models.Proposal) error {
 	query := `
 		UPDATE proposal
-		SET title = $2, description = $3, tender_id = $4, organization_id = $5, author_id = $6, version = version + 1, updated_at = $7
+		SET title = $2, description = $3, tender_id = $4, organization_id = $5, author_id = $6, version = version + 1, updated_at = $7,
+			price = COALESCE($8, price), currency = COALESCE($9, currency), sealed_content = $10,
+			lot_ids = COALESCE(string_to_array($11, ',')::uuid[], lot_ids), tender_version = (SELECT t.version FROM tender t WHERE t.id = $4)
 		WHERE id = $1
+		RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 	`
 
-	_, err := repo.DB.Exec(query, proposal.ID, proposal.Title, proposal.Description, proposal.TenderID, proposal.OrganizationID, proposal.AuthorID, time.Now())
+	updated, err := repo.updateProposal(ctx, "ProposalRepository.EditProposal", "edit", proposal.ID, query, proposal.Title, proposal.Description, proposal.TenderID, proposal.OrganizationID, proposal.AuthorID, time.Now(),
+		proposal.Price, proposal.Currency, proposal.SealedContent, proposal.LotIDs)
 	if err != nil {
 		return errors.Wrap(err, "failed to edit proposal")
 	}
 
+	*proposal = *updated
+
 	return nil
 }
 
-func (repo *ProposalRepository) AgreeProposal(proposalID uuid.UUID) error {
+func (repo *ProposalRepository) AcknowledgeAmendment(ctx context.Context, proposalID uuid.UUID) (*models.Proposal, error) {
+	query := `
+		UPDATE proposal
+		SET tender_version = (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id), updated_at = $2
+		WHERE id = $1
+		RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
+	`
+
+	proposal, err := repo.updateProposal(ctx, "ProposalRepository.AcknowledgeAmendment", "acknowledge", proposalID, query, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to acknowledge tender amendment")
+	}
+
+	return proposal, nil
+}
+
+func (repo *ProposalRepository) AgreeProposal(ctx context.Context, proposalID uuid.UUID) error {
 	query := `
 		UPDATE proposal
 		SET status = 'AGREED', updated_at = $2
 		WHERE id = $1
+		RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 	`
 
-	_, err := repo.DB.Exec(query, proposalID, time.Now())
+	_, err := repo.updateProposal(ctx, "ProposalRepository.AgreeProposal", "agree", proposalID, query, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to agree proposal")
 	}
@@ -116,14 +162,16 @@ func (repo *ProposalRepository) AgreeProposal(proposalID uuid.UUID) error {
 	return nil
 }
 
-func (repo *ProposalRepository) DeclineProposal(proposalID uuid.UUID) error {
+func (repo *ProposalRepository) DeclineProposal(ctx context.Context, proposalID uuid.UUID) error {
 	query := `
 		UPDATE proposal
 		SET status = 'DECLINED', updated_at = $2
 		WHERE id = $1
+		RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 	`
 
-	_, err := repo.DB.Exec(query, proposalID, time.Now())
+	_, err := repo.updateProposal(ctx, "ProposalRepository.DeclineProposal", "decline", proposalID, query, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to decline proposal")
 	}
@@ -131,15 +179,342 @@ func (repo *ProposalRepository) DeclineProposal(proposalID uuid.UUID) error {
 	return nil
 }
 
-func (repo *ProposalRepository) GetProposalByID(proposalID uuid.UUID) (*models.Proposal, error) {
+// updateProposal locks the proposal, applies the update query, which takes the proposal ID as $1 and
+// returns the updated row, and records the change in the audit log in the same transaction.
+func (repo *ProposalRepository) updateProposal(ctx context.Context, operation string, action string, proposalID uuid.UUID, query string, args ...interface{}) (*models.Proposal, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	before, err := lockProposal(ctx, tx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var after models.Proposal
+	spanCtx, span := startSpan(ctx, operation, query)
+	err = tx.GetContext(spanCtx, &after, query, append([]interface{}{proposalID}, args...)...)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update proposal")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         action,
+		EntityType:     auditEntityProposal,
+		EntityID:       proposalID,
+		OrganizationID: after.OrganizationID,
+		Before:         before,
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &after, nil
+}
+
+func lockProposal(ctx context.Context, tx *sqlx.Tx, proposalID uuid.UUID) (*models.Proposal, error) {
+	query := `
+		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
+		FROM proposal
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var proposal models.Proposal
+	ctx, span := startSpan(ctx, "lockProposal", query)
+	err := tx.GetContext(ctx, &proposal, query, proposalID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get proposal")
+	}
+
+	return &proposal, nil
+}
+
+// SubmitDecision records the responsible's decision on a published proposal. A rejection declines
+// the proposal at once; approvals agree it when they reach the quorum, min(3, number of responsibles
+// allowed to decide), and then the tender is closed. On a tender with lots an approved proposal is
+// awarded the lots it is for, and the tender is closed once every lot is awarded. A stale proposal
+// can't be approved.
+func (repo *ProposalRepository) SubmitDecision(ctx context.Context, proposalID uuid.UUID, authorID uuid.UUID, decision models.Decision) (*models.Proposal, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	before, err := lockProposal(ctx, tx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := lockTender(ctx, tx, before.TenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := *before
+	if proposal.Status != "PUBLISHED" {
+		return nil, errors.Wrap(models.ErrInvalidState, "only published proposals can be decided")
+	}
+
+	if tender.Status == "CLOSED" {
+		return nil, errors.Wrap(models.ErrInvalidState, "decisions on closed tenders are not accepted")
+	}
+
+	if tender.BidsSealed() {
+		return nil, errors.Wrap(models.ErrInvalidState, "bids of the tender are not opened yet")
+	}
+
+	lots, err := getLots(ctx, tx, tender.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if decision == models.DecisionApproved {
+		if proposal.TenderVersion < tender.Version {
+			return nil, errors.Wrap(models.ErrInvalidState, "the tender was amended after the proposal was submitted: the author must acknowledge the amendment or revise the proposal")
+		}
+
+		for _, lot := range lots {
+			if lot.Status == models.LotAwarded && slices.Contains(proposal.LotIDs, lot.ID) {
+				return nil, errors.Wrapf(models.ErrInvalidState, "lot %s is already awarded", lot.Title)
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO proposal_decision (id, proposal_id, author_id, decision, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (proposal_id, author_id) DO UPDATE
+		SET decision = EXCLUDED.decision, created_at = EXCLUDED.created_at
+	`
+
+	now := time.Now()
+	spanCtx, span := startSpan(ctx, "ProposalRepository.SubmitDecision", query)
+	_, err = tx.ExecContext(spanCtx, query, uuid.New(), proposalID, authorID, string(decision), now)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save decision")
+	}
+
+	status := ""
+	if decision == models.DecisionRejected {
+		status = "DECLINED"
+	} else {
+		reached, err := repo.quorumReached(ctx, tx, proposal)
+		if err != nil {
+			return nil, err
+		}
+
+		if reached {
+			status = "AGREED"
+		}
+	}
+
+	if status != "" {
+		query = `
+			UPDATE proposal
+			SET status = $2, updated_at = $3
+			WHERE id = $1
+		`
+
+		spanCtx, span = startSpan(ctx, "ProposalRepository.SubmitDecision", query)
+		_, err = tx.ExecContext(spanCtx, query, proposalID, status, now)
+		endSpan(span, err)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to update proposal status")
+		}
+
+		proposal.Status = status
+		proposal.UpdatedAt = now
+	}
+
+	change := auditChange{
+		Action:         "decision_" + strings.ToLower(string(decision)),
+		EntityType:     auditEntityProposal,
+		EntityID:       proposalID,
+		OrganizationID: tender.OrganizationID,
+		Before:         before,
+		After:          proposal,
+	}
+	if err = recordChange(ctx, tx, change); err != nil {
+		return nil, err
+	}
+
+	if status != "" {
+		eventType := models.EventBidApproved
+		if status == "DECLINED" {
+			eventType = models.EventBidRejected
+		}
+
+		if err = writeOutboxEvent(ctx, tx, eventType, change); err != nil {
+			return nil, err
+		}
+	}
+
+	closeTender := status == "AGREED"
+	if closeTender && len(lots) > 0 {
+		closeTender, err = awardLots(ctx, tx, tender, lots, proposal, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if closeTender {
+		query = `
+			UPDATE tender
+			SET status = 'CLOSED', updated_at = $2
+			WHERE id = $1
+		`
+
+		spanCtx, span = startSpan(ctx, "ProposalRepository.SubmitDecision", query)
+		_, err = tx.ExecContext(spanCtx, query, proposal.TenderID, now)
+		endSpan(span, err)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to close tender")
+		}
+
+		closed := *tender
+		closed.Status = "CLOSED"
+		closed.UpdatedAt = now
+		err = recordChange(ctx, tx, auditChange{
+			Action:         "close",
+			EntityType:     auditEntityTender,
+			EntityID:       tender.ID,
+			OrganizationID: tender.OrganizationID,
+			Before:         tender,
+			After:          closed,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &proposal, nil
+}
+
+func (repo *ProposalRepository) quorumReached(ctx context.Context, tx *sqlx.Tx, proposal models.Proposal) (bool, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM proposal_decision
+		WHERE proposal_id = $1 AND decision = $2
+	`
+
+	var approvals int
+	spanCtx, span := startSpan(ctx, "ProposalRepository.quorumReached", query)
+	err := tx.GetContext(spanCtx, &approvals, query, proposal.ID, string(models.DecisionApproved))
+	endSpan(span, err)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to count approvals")
+	}
+
+	query = `
+		SELECT org_res.role
+		FROM organization_responsible org_res
+		JOIN tender t ON t.organization_id = org_res.organization_id
+		JOIN employee e ON org_res.user_id = e.id
+		WHERE t.id = $1 AND e.deactivated_at IS NULL
+	`
+
+	var roles []models.ResponsibleRole
+	spanCtx, span = startSpan(ctx, "ProposalRepository.quorumReached", query)
+	err = tx.SelectContext(spanCtx, &roles, query, proposal.TenderID)
+	endSpan(span, err)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get tender responsibles")
+	}
+
+	deciders := 0
+	for _, role := range roles {
+		if role.Can(models.PermissionDecideBids) {
+			deciders++
+		}
+	}
+
+	return approvals >= min(3, deciders), nil
+}
+
+func (repo *ProposalRepository) AddFeedback(ctx context.Context, feedback *models.ProposalFeedback) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO proposal_feedback (id, proposal_id, author_id, description, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	feedback.ID = uuid.New()
+	feedback.CreatedAt = time.Now()
+
+	spanCtx, span := startSpan(ctx, "ProposalRepository.AddFeedback", query)
+	_, err = tx.ExecContext(spanCtx, query, feedback.ID, feedback.ProposalID, feedback.AuthorID, feedback.Description, feedback.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to add feedback")
+	}
+
+	query = `
+		SELECT t.organization_id
+		FROM proposal p
+		JOIN tender t ON p.tender_id = t.id
+		WHERE p.id = $1
+	`
+
+	var organizationID uuid.UUID
+	spanCtx, span = startSpan(ctx, "ProposalRepository.AddFeedback", query)
+	err = tx.GetContext(spanCtx, &organizationID, query, feedback.ProposalID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tender organization")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityProposalFeedback,
+		EntityID:       feedback.ID,
+		OrganizationID: organizationID,
+		After:          feedback,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *ProposalRepository) GetProposalByID(ctx context.Context, proposalID uuid.UUID) (*models.Proposal, error) {
 	query := `
-		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at
+		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 		FROM proposal
 		WHERE id = $1
 	`
 
 	var proposal models.Proposal
-	err := repo.DB.Get(&proposal, query, proposalID)
+	ctx, span := startSpan(ctx, "ProposalRepository.GetProposalByID", query)
+	err := repo.DB.GetContext(ctx, &proposal, query, proposalID)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get proposal")
 	}
@@ -147,15 +522,30 @@ func (repo *ProposalRepository) GetProposalByID(proposalID uuid.UUID) (*models.P
 	return &proposal, nil
 }
 
-func (repo *ProposalRepository) GetProposalsByTender(tenderID uuid.UUID) ([]models.Proposal, error) {
+// proposalOrders maps the list orders to ORDER BY clauses; proposals without a price go last.
+var proposalOrders = map[models.ProposalSort]string{
+	models.ProposalSortCreated:   "created_at, id",
+	models.ProposalSortPriceAsc:  "price ASC NULLS LAST, created_at, id",
+	models.ProposalSortPriceDesc: "price DESC NULLS LAST, created_at, id",
+}
+
+func (repo *ProposalRepository) GetProposalsByTender(ctx context.Context, tenderID uuid.UUID, sort models.ProposalSort) ([]models.Proposal, error) {
+	order, ok := proposalOrders[sort]
+	if !ok {
+		return nil, errors.Errorf("unknown proposal order %q", sort)
+	}
+
 	query := `
-		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at
+		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
 		FROM proposal
 		WHERE tender_id = $1 AND status = 'PUBLISHED'
-	`
+		ORDER BY ` + order
 
 	var proposals []models.Proposal
-	err := repo.DB.Select(&proposals, query, tenderID)
+	ctx, span := startSpan(ctx, "ProposalRepository.GetProposalsByTender", query)
+	err := repo.DB.SelectContext(ctx, &proposals, query, tenderID)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get proposals by tender")
 	}
@@ -163,16 +553,39 @@ func (repo *ProposalRepository) GetProposalsByTender(tenderID uuid.UUID) ([]mode
 	return proposals, nil
 }
 
-func (repo *ProposalRepository) GetProposalsByUsername(username string) ([]models.Proposal, error) {
+// GetSealedProposals returns the proposals on the tender whose contents are still encrypted.
+func (repo *ProposalRepository) GetSealedProposals(ctx context.Context, tenderID uuid.UUID) ([]models.Proposal, error) {
+	query := `
+		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
+		FROM proposal
+		WHERE tender_id = $1 AND sealed_content IS NOT NULL
+	`
+
+	var proposals []models.Proposal
+	ctx, span := startSpan(ctx, "ProposalRepository.GetSealedProposals", query)
+	err := repo.DB.SelectContext(ctx, &proposals, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sealed proposals")
+	}
+
+	return proposals, nil
+}
+
+func (repo *ProposalRepository) GetProposalsByUsername(ctx context.Context, username string) ([]models.Proposal, error) {
 	query := `
-        SELECT p.id, p.title, p.description, p.tender_id, p.organization_id, p.author_id, p.status, p.version, p.created_at, p.updated_at
+        SELECT p.id, p.title, p.description, p.tender_id, p.organization_id, p.author_id, p.status, p.version, p.created_at, p.updated_at, p.price, p.currency, p.sealed_content, p.sealed, array_to_string(p.lot_ids, ',') AS lot_ids,
+            p.tender_version, p.tender_version < (SELECT t.version FROM tender t WHERE t.id = p.tender_id) AS stale
         FROM proposal p
         JOIN employee e ON p.author_id = e.id
         WHERE e.username = $1
     `
 
 	var proposals []models.Proposal
-	err := repo.DB.Select(&proposals, query, username)
+	ctx, span := startSpan(ctx, "ProposalRepository.GetProposalsByUsername", query)
+	err := repo.DB.SelectContext(ctx, &proposals, query, username)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get proposals by username")
 	}
@@ -180,24 +593,24 @@ func (repo *ProposalRepository) GetProposalsByUsername(username string) ([]model
 	return proposals, nil
 }
 
-func (repo *ProposalRepository) RollbackProposal(bidID uuid.UUID, version int) (*models.Proposal, error) {
+func (repo *ProposalRepository) RollbackProposal(ctx context.Context, bidID uuid.UUID, version int) (*models.Proposal, error) {
 	query := `
         UPDATE proposal
-        SET version = $1
-        WHERE id = $2
-        RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at
+        SET version = $2
+        WHERE id = $1
+        RETURNING id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+            tender_version, tender_version < (SELECT t.version FROM tender t WHERE t.id = proposal.tender_id) AS stale
     `
 
-	var rolledBackProposal models.Proposal
-	err := repo.DB.Get(&rolledBackProposal, query, version, bidID)
+	rolledBackProposal, err := repo.updateProposal(ctx, "ProposalRepository.RollbackProposal", "rollback", bidID, query, version)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to rollback proposal")
 	}
 
-	return &rolledBackProposal, nil
+	return rolledBackProposal, nil
 }
 
-func (repo *ProposalRepository) GetProposalStatus(proposalID uuid.UUID) (string, error) {
+func (repo *ProposalRepository) GetProposalStatus(ctx context.Context, proposalID uuid.UUID) (string, error) {
 	var status string
 	query := `
 		SELECT status
@@ -205,7 +618,9 @@ func (repo *ProposalRepository) GetProposalStatus(proposalID uuid.UUID) (string,
 		WHERE id = $1
 	`
 
-	err := repo.DB.Get(&status, query, proposalID)
+	ctx, span := startSpan(ctx, "ProposalRepository.GetProposalStatus", query)
+	err := repo.DB.GetContext(ctx, &status, query, proposalID)
+	endSpan(span, err)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get proposal status")
 	}
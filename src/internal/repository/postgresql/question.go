@@ -0,0 +1,207 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type QuestionRepository struct {
+	DB *sqlx.DB
+}
+
+func NewQuestionRepository(db *sqlx.DB) _interface.QuestionRepository {
+	return &QuestionRepository{
+		DB: db,
+	}
+}
+
+func (repo *QuestionRepository) CreateQuestion(ctx context.Context, question *models.TenderQuestion) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO tender_question (id, tender_id, organization_id, asked_by, question, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	question.ID = uuid.New()
+	question.CreatedAt = time.Now()
+
+	spanCtx, span := startSpan(ctx, "QuestionRepository.CreateQuestion", query)
+	_, err = tx.ExecContext(spanCtx, query, question.ID, question.TenderID, question.OrganizationID, question.AskedBy, question.Question, question.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to create question")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityTenderQuestion,
+		EntityID:       question.ID,
+		OrganizationID: question.OrganizationID,
+		After:          question,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *QuestionRepository) GetQuestions(ctx context.Context, tenderID uuid.UUID, username string, limit, offset int) ([]models.TenderQuestion, error) {
+	query := `
+		SELECT q.id, q.tender_id, q.organization_id, q.asked_by, q.question, q.answer, q.answered_by, q.answered_at, q.amendment_version, q.amendment_note, q.created_at
+		FROM tender_question q
+		JOIN tender t ON t.id = q.tender_id
+		WHERE q.tender_id = $1 AND (q.answered_at IS NOT NULL OR EXISTS (
+			SELECT 1
+			FROM organization_responsible org_res
+			JOIN employee e ON org_res.user_id = e.id
+			WHERE e.username = $2 AND org_res.organization_id IN (q.organization_id, t.organization_id)
+		))
+		ORDER BY q.created_at, q.id
+		LIMIT $3 OFFSET $4
+	`
+
+	questions := []models.TenderQuestion{}
+	ctx, span := startSpan(ctx, "QuestionRepository.GetQuestions", query)
+	err := repo.DB.SelectContext(ctx, &questions, query, tenderID, username, limit, offset)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get questions")
+	}
+
+	return questions, nil
+}
+
+// AnswerQuestion locks the tender before the question, so the amendment version is taken from the
+// tender as it is when the answer is recorded.
+func (repo *QuestionRepository) AnswerQuestion(ctx context.Context, tenderID uuid.UUID, questionID uuid.UUID, request models.AnswerQuestionRequest, answeredBy uuid.UUID) (*models.TenderQuestion, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tender, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, tender_id, organization_id, asked_by, question, answer, answered_by, answered_at, amendment_version, amendment_note, created_at
+		FROM tender_question
+		WHERE id = $1 AND tender_id = $2
+		FOR UPDATE
+	`
+
+	var before models.TenderQuestion
+	spanCtx, span := startSpan(ctx, "QuestionRepository.AnswerQuestion", query)
+	err = tx.GetContext(spanCtx, &before, query, questionID, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get question")
+	}
+
+	if before.AnsweredAt != nil {
+		return nil, errors.Wrap(models.ErrInvalidState, "question is already answered")
+	}
+
+	if tender.Status != "PUBLISHED" {
+		return nil, errors.Wrap(models.ErrInvalidState, "questions are only answered on published tenders")
+	}
+
+	now := time.Now()
+	var amendmentVersion *int
+	if request.AmendmentNote != nil {
+		amended, err := amendTender(ctx, tx, tender, now)
+		if err != nil {
+			return nil, err
+		}
+		amendmentVersion = &amended.Version
+	}
+
+	query = `
+		UPDATE tender_question
+		SET answer = $2, answered_by = $3, answered_at = $4, amendment_version = $5, amendment_note = $6
+		WHERE id = $1
+		RETURNING id, tender_id, organization_id, asked_by, question, answer, answered_by, answered_at, amendment_version, amendment_note, created_at
+	`
+
+	var after models.TenderQuestion
+	spanCtx, span = startSpan(ctx, "QuestionRepository.AnswerQuestion", query)
+	err = tx.GetContext(spanCtx, &after, query, questionID, request.Answer, answeredBy, now, amendmentVersion, request.AmendmentNote)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to answer question")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "answer",
+		EntityType:     auditEntityTenderQuestion,
+		EntityID:       questionID,
+		OrganizationID: tender.OrganizationID,
+		Before:         before,
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &after, nil
+}
+
+// amendTender creates a new version of the locked tender without changing its terms: the amendment
+// itself is described by the answer that caused it. Bids on the earlier versions become stale.
+func amendTender(ctx context.Context, tx *sqlx.Tx, tender *models.Tender, now time.Time) (*models.Tender, error) {
+	query := `
+		UPDATE tender
+		SET version = version + 1, updated_at = $2
+		WHERE id = $1
+		RETURNING id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
+	`
+
+	var amended models.Tender
+	spanCtx, span := startSpan(ctx, "amendTender", query)
+	err := tx.GetContext(spanCtx, &amended, query, tender.ID, now)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to amend tender")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "amend",
+		EntityType:     auditEntityTender,
+		EntityID:       tender.ID,
+		OrganizationID: tender.OrganizationID,
+		Before:         tender,
+		After:          amended,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = markProposalsStale(ctx, tx, tender, &amended); err != nil {
+		return nil, err
+	}
+
+	return &amended, nil
+}
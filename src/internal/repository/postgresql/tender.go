@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,28 +21,17 @@ func NewTenderRepository(db *sqlx.DB) *TenderRepository {
 	}
 }
 
-func (repo *TenderRepository) CheckUserBelongsToOrganization(orgID uuid.UUID, username string) (bool, error) {
-	query := `
-		SELECT COUNT(*) > 0
-		FROM organization_responsible org_res
-		WHERE org_res.organization_id = $1 AND org_res.user_id = (
-			SELECT id FROM employee WHERE username = $2
-		)
-	`
-
-	var exists bool
-	err := repo.DB.Get(&exists, query, orgID, username)
+func (repo *TenderRepository) CreateTender(ctx context.Context, tender *models.Tender) (*models.Tender, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to check user organization membership")
+		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
+	defer tx.Rollback()
 
-	return exists, nil
-}
-
-func (repo *TenderRepository) CreateTender(tender *models.Tender) (*models.Tender, error) {
 	query := `
-		INSERT INTO tender (id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO tender (id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed,
+			kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 	`
 
 	tender.ID = uuid.New()
@@ -50,22 +40,56 @@ func (repo *TenderRepository) CreateTender(tender *models.Tender) (*models.Tende
 	tender.CreatedAt = time.Now()
 	tender.UpdatedAt = time.Now()
 
-	_, err := repo.DB.Exec(query, tender.ID, tender.Title, tender.Description, tender.Status, tender.OrganizationID, tender.Version, tender.CreatedAt, tender.UpdatedAt, tender.ServiceType, tender.CreatorUsername)
+	spanCtx, span := startSpan(ctx, "TenderRepository.CreateTender", query)
+	_, err = tx.ExecContext(spanCtx, query, tender.ID, tender.Title, tender.Description, tender.Status, tender.OrganizationID, tender.Version, tender.CreatedAt, tender.UpdatedAt, tender.ServiceType, tender.CreatorUsername, tender.SubmissionDeadline, tender.DecisionDeadline, tender.BudgetMin, tender.BudgetMax, tender.Currency, tender.Sealed,
+		string(tender.Kind), tender.MinDecrement, tender.RoundDuration, tender.SnipingExtension, tender.AuctionStartsAt, string(tender.Visibility))
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create tender")
 	}
 
+	if tender.Kind == models.TenderAuction {
+		query = `
+			INSERT INTO auction (tender_id, round_ends_at)
+			VALUES ($1, $2)
+		`
+
+		roundEndsAt := tender.AuctionStartsAt.Add(time.Duration(*tender.RoundDuration) * time.Second)
+		spanCtx, span = startSpan(ctx, "TenderRepository.CreateTender", query)
+		_, err = tx.ExecContext(spanCtx, query, tender.ID, roundEndsAt)
+		endSpan(span, err)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create auction")
+		}
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "create",
+		EntityType:     auditEntityTender,
+		EntityID:       tender.ID,
+		OrganizationID: tender.OrganizationID,
+		After:          tender,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
 	return tender, nil
 }
 
-func (repo *TenderRepository) PublishTender(tenderID uuid.UUID) error {
+func (repo *TenderRepository) PublishTender(ctx context.Context, tenderID uuid.UUID) error {
 	query := `
 		UPDATE tender
 		SET status = 'PUBLISHED', updated_at = $2
 		WHERE id = $1
+		RETURNING id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 	`
 
-	_, err := repo.DB.Exec(query, tenderID, time.Now())
+	_, err := repo.updateTender(ctx, "TenderRepository.PublishTender", "publish", tenderID, query, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to publish tender")
 	}
@@ -73,14 +97,15 @@ func (repo *TenderRepository) PublishTender(tenderID uuid.UUID) error {
 	return nil
 }
 
-func (repo *TenderRepository) CloseTender(tenderID uuid.UUID) error {
+func (repo *TenderRepository) CloseTender(ctx context.Context, tenderID uuid.UUID) error {
 	query := `
 		UPDATE tender
 		SET status = 'CLOSED', updated_at = $2
 		WHERE id = $1
+		RETURNING id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 	`
 
-	_, err := repo.DB.Exec(query, tenderID, time.Now())
+	_, err := repo.updateTender(ctx, "TenderRepository.CloseTender", "close", tenderID, query, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to close tender")
 	}
@@ -88,30 +113,374 @@ func (repo *TenderRepository) CloseTender(tenderID uuid.UUID) error {
 	return nil
 }
 
-func (repo *TenderRepository) EditTender(tender *models.Tender) error {
+// EditTender updates the tender and fills it with the stored values. Deadlines and budget are only changed when set.
+// Editing a published tender amends it: the bids submitted against earlier versions become stale.
+func (repo *TenderRepository) EditTender(ctx context.Context, tender *models.Tender) error {
 	query := `
 		UPDATE tender
-		SET title = $2, description = $3, version = version + 1, updated_at = $4
+		SET title = $2, description = $3, version = version + 1, updated_at = $4,
+			submission_deadline = COALESCE($5, submission_deadline), decision_deadline = COALESCE($6, decision_deadline),
+			budget_min = COALESCE($7, budget_min), budget_max = COALESCE($8, budget_max), currency = COALESCE($9, currency)
 		WHERE id = $1
+		RETURNING id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 	`
 
-	_, err := repo.DB.Exec(query, tender.ID, tender.Title, tender.Description, time.Now())
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	before, updated, err := updateTenderTx(ctx, tx, "TenderRepository.EditTender", "edit", tender.ID, query, tender.Title, tender.Description, time.Now(),
+		tender.SubmissionDeadline, tender.DecisionDeadline, tender.BudgetMin, tender.BudgetMax, tender.Currency)
 	if err != nil {
 		return errors.Wrap(err, "failed to edit tender")
 	}
 
+	if before.Status == "PUBLISHED" {
+		if err = markProposalsStale(ctx, tx, before, updated); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	*tender = *updated
+
 	return nil
 }
 
-func (repo *TenderRepository) GetTenderByID(tenderID uuid.UUID) (*models.Tender, error) {
+// tenderDeadlineLockKey is the advisory lock that lets one service instance at a time close expired tenders.
+const tenderDeadlineLockKey int64 = 4002
+
+// CloseExpiredTenders closes up to limit published tenders whose decision deadline has passed. Tenders
+// without a decision deadline stay open for decisions after submissions end and are closed by hand.
+// Sealed tenders are closed only once their bids are opened, so they can be decided on.
+// It returns at once if another instance holds the lock.
+func (repo *TenderRepository) CloseExpiredTenders(ctx context.Context, now time.Time, limit int) (int, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `SELECT pg_try_advisory_xact_lock($1)`
+
+	var locked bool
+	spanCtx, span := startSpan(ctx, "TenderRepository.CloseExpiredTenders", query)
+	err = tx.GetContext(spanCtx, &locked, query, tenderDeadlineLockKey)
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to take deadline lock")
+	}
+
+	if !locked {
+		return 0, nil
+	}
+
+	query = `
+		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
+		FROM tender
+		WHERE status = 'PUBLISHED' AND decision_deadline <= $1 AND NOT (sealed AND bids_opened_at IS NULL)
+		ORDER BY decision_deadline
+		LIMIT $2
+		FOR UPDATE
+	`
+
+	var expired []models.Tender
+	spanCtx, span = startSpan(ctx, "TenderRepository.CloseExpiredTenders", query)
+	err = tx.SelectContext(spanCtx, &expired, query, now, limit)
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get expired tenders")
+	}
+
+	query = `
+		UPDATE tender
+		SET status = 'CLOSED', updated_at = $2
+		WHERE id = $1
+	`
+
+	for _, tender := range expired {
+		spanCtx, span = startSpan(ctx, "TenderRepository.CloseExpiredTenders", query)
+		_, err = tx.ExecContext(spanCtx, query, tender.ID, now)
+		endSpan(span, err)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to close expired tender")
+		}
+
+		closed := tender
+		closed.Status = "CLOSED"
+		closed.UpdatedAt = now
+		err = recordChange(ctx, tx, auditChange{
+			Action:         "close",
+			EntityType:     auditEntityTender,
+			EntityID:       tender.ID,
+			OrganizationID: tender.OrganizationID,
+			Before:         tender,
+			After:          closed,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return len(expired), nil
+}
+
+func (repo *TenderRepository) GetTendersToOpen(ctx context.Context, now time.Time, limit int) ([]models.Tender, error) {
 	query := `
-		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username
+		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
+		FROM tender
+		WHERE sealed AND bids_opened_at IS NULL AND submission_deadline <= $1
+		ORDER BY submission_deadline
+		LIMIT $2
+	`
+
+	var tenders []models.Tender
+	ctx, span := startSpan(ctx, "TenderRepository.GetTendersToOpen", query)
+	err := repo.DB.SelectContext(ctx, &tenders, query, now, limit)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenders to open")
+	}
+
+	return tenders, nil
+}
+
+func (repo *TenderRepository) OpenBids(ctx context.Context, tenderID uuid.UUID, opened []models.Proposal) error {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tender, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	if !tender.BidsSealed() {
+		return errors.Wrap(models.ErrInvalidState, "bids of the tender are not sealed")
+	}
+
+	// The guard on sealed_content fails the opening if a proposal was edited after it was decrypted.
+	query := `
+		UPDATE proposal
+		SET title = $2, description = $3, price = $4, currency = $5, sealed_content = NULL
+		WHERE id = $1 AND tender_id = $6 AND sealed_content = $7
+	`
+
+	for _, proposal := range opened {
+		spanCtx, span := startSpan(ctx, "TenderRepository.OpenBids", query)
+		result, err := tx.ExecContext(spanCtx, query, proposal.ID, proposal.Title, proposal.Description, proposal.Price, proposal.Currency, tenderID, proposal.SealedContent)
+		endSpan(span, err)
+		if err != nil {
+			return errors.Wrap(err, "failed to open proposal")
+		}
+
+		if affected, err := result.RowsAffected(); err != nil || affected != 1 {
+			return errors.Errorf("proposal %s changed while the bids were being opened", proposal.ID)
+		}
+
+		before := proposal
+		before.SetContent(models.ProposalContent{})
+		after := proposal
+		after.SealedContent = nil
+		after.Sealed = false
+		err = recordChange(ctx, tx, auditChange{
+			Action:         "open",
+			EntityType:     auditEntityProposal,
+			EntityID:       proposal.ID,
+			OrganizationID: proposal.OrganizationID,
+			Before:         before,
+			After:          after,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	query = `
+		SELECT COUNT(*)
+		FROM proposal
+		WHERE tender_id = $1 AND sealed_content IS NOT NULL
+	`
+
+	var remaining int
+	spanCtx, span := startSpan(ctx, "TenderRepository.OpenBids", query)
+	err = tx.GetContext(spanCtx, &remaining, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to count sealed proposals")
+	}
+
+	if remaining > 0 {
+		return errors.Errorf("%d proposals were sealed while the bids were being opened", remaining)
+	}
+
+	now := time.Now()
+	query = `
+		UPDATE tender
+		SET bids_opened_at = $2, updated_at = $2
+		WHERE id = $1
+	`
+
+	spanCtx, span = startSpan(ctx, "TenderRepository.OpenBids", query)
+	_, err = tx.ExecContext(spanCtx, query, tenderID, now)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to open tender bids")
+	}
+
+	after := *tender
+	after.BidsOpenedAt = &now
+	after.UpdatedAt = now
+	err = recordChange(ctx, tx, auditChange{
+		Action:         "open_bids",
+		EntityType:     auditEntityTender,
+		EntityID:       tenderID,
+		OrganizationID: tender.OrganizationID,
+		Before:         tender,
+		After:          after,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// updateTender locks the tender, applies the update query, which takes the tender ID as $1 and
+// returns the updated row, and records the change in the audit log in the same transaction.
+func (repo *TenderRepository) updateTender(ctx context.Context, operation string, action string, tenderID uuid.UUID, query string, args ...interface{}) (*models.Tender, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	_, after, err := updateTenderTx(ctx, tx, operation, action, tenderID, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return after, nil
+}
+
+// updateTenderTx is updateTender within the caller's transaction. It returns the tender before and
+// after the update.
+func updateTenderTx(ctx context.Context, tx *sqlx.Tx, operation string, action string, tenderID uuid.UUID, query string, args ...interface{}) (*models.Tender, *models.Tender, error) {
+	before, err := lockTender(ctx, tx, tenderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var after models.Tender
+	spanCtx, span := startSpan(ctx, operation, query)
+	err = tx.GetContext(spanCtx, &after, query, append([]interface{}{tenderID}, args...)...)
+	endSpan(span, err)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to update tender")
+	}
+
+	err = recordChange(ctx, tx, auditChange{
+		Action:         action,
+		EntityType:     auditEntityTender,
+		EntityID:       tenderID,
+		OrganizationID: after.OrganizationID,
+		Before:         before,
+		After:          after,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return before, &after, nil
+}
+
+// markProposalsStale records in the audit log that the drafts and published bids submitted against an
+// earlier version of the amended tender are stale, which notifies their authors. The proposals are not
+// locked: staleness is derived from the tender version, so concurrent changes of the bids don't matter.
+func markProposalsStale(ctx context.Context, tx *sqlx.Tx, before *models.Tender, amended *models.Tender) error {
+	query := `
+		SELECT id, title, description, tender_id, organization_id, author_id, status, version, created_at, updated_at, price, currency, sealed_content, sealed, array_to_string(lot_ids, ',') AS lot_ids,
+			tender_version, tender_version < $2 AS stale
+		FROM proposal
+		WHERE tender_id = $1 AND status IN ('CREATED', 'PUBLISHED') AND tender_version < $2
+	`
+
+	var proposals []models.Proposal
+	spanCtx, span := startSpan(ctx, "markProposalsStale", query)
+	err := tx.SelectContext(spanCtx, &proposals, query, amended.ID, amended.Version)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to get stale proposals")
+	}
+
+	for _, proposal := range proposals {
+		previous := proposal
+		previous.Stale = proposal.TenderVersion < before.Version
+		err = recordChange(ctx, tx, auditChange{
+			Action:         "stale",
+			EntityType:     auditEntityProposal,
+			EntityID:       proposal.ID,
+			OrganizationID: proposal.OrganizationID,
+			Before:         previous,
+			After:          proposal,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lockTender(ctx context.Context, tx *sqlx.Tx, tenderID uuid.UUID) (*models.Tender, error) {
+	query := `
+		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
+		FROM tender
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var tender models.Tender
+	ctx, span := startSpan(ctx, "lockTender", query)
+	err := tx.GetContext(ctx, &tender, query, tenderID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tender")
+	}
+
+	return &tender, nil
+}
+
+func (repo *TenderRepository) GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*models.Tender, error) {
+	query := `
+		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 		FROM tender
 		WHERE id = $1
 	`
 
 	var tenderRepo models.Tender
-	err := repo.DB.Get(&tenderRepo, query, tenderID)
+	ctx, span := startSpan(ctx, "TenderRepository.GetTenderByID", query)
+	err := repo.DB.GetContext(ctx, &tenderRepo, query, tenderID)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get tender")
 	}
@@ -119,27 +488,30 @@ func (repo *TenderRepository) GetTenderByID(tenderID uuid.UUID) (*models.Tender,
 	return &tenderRepo, nil
 }
 
-func (repo *TenderRepository) GetTenders(serviceType string) ([]models.Tender, error) {
+// GetTenders returns the published public tenders and the published private tenders visible to the user.
+func (repo *TenderRepository) GetTenders(ctx context.Context, serviceType string, username string) ([]models.Tender, error) {
 	var query string
-	var args []interface{}
+	args := []interface{}{username}
 
 	if serviceType != "" {
 		query = `
-			SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username
+			SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 			FROM tender
-			WHERE service_type = $1 AND status = 'PUBLISHED'
+			WHERE service_type = $2 AND status = 'PUBLISHED' AND (visibility = 'PUBLIC' OR tender_visible_to(id, $1))
 		`
 		args = append(args, serviceType)
 	} else {
 		query = `
-			SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username
+			SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 			FROM tender
-			WHERE status = 'PUBLISHED'
+			WHERE status = 'PUBLISHED' AND (visibility = 'PUBLIC' OR tender_visible_to(id, $1))
 		`
 	}
 
 	var tenders []models.Tender
-	err := repo.DB.Select(&tenders, query, args...)
+	ctx, span := startSpan(ctx, "TenderRepository.GetTenders", query)
+	err := repo.DB.SelectContext(ctx, &tenders, query, args...)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get tenders")
 	}
@@ -147,9 +519,9 @@ func (repo *TenderRepository) GetTenders(serviceType string) ([]models.Tender, e
 	return tenders, nil
 }
 
-func (repo *TenderRepository) GetMyTenders(username string) ([]models.Tender, error) {
+func (repo *TenderRepository) GetMyTenders(ctx context.Context, username string) ([]models.Tender, error) {
 	query := `
-		SELECT t.id, t.title, t.description, t.status, t.organization_id, t.version, t.created_at, t.updated_at, service_type, creator_username
+		SELECT t.id, t.title, t.description, t.status, t.organization_id, t.version, t.created_at, t.updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 		FROM tender t
 		JOIN organization_responsible org_res ON t.organization_id = org_res.organization_id
 		JOIN employee e ON org_res.user_id = e.id
@@ -157,7 +529,9 @@ func (repo *TenderRepository) GetMyTenders(username string) ([]models.Tender, er
 	`
 
 	var tenders []models.Tender
-	err := repo.DB.Select(&tenders, query, username)
+	ctx, span := startSpan(ctx, "TenderRepository.GetMyTenders", query)
+	err := repo.DB.SelectContext(ctx, &tenders, query, username)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get my tenders")
 	}
@@ -165,15 +539,17 @@ func (repo *TenderRepository) GetMyTenders(username string) ([]models.Tender, er
 	return tenders, nil
 }
 
-func (repo *TenderRepository) RollbackTender(tenderID uuid.UUID, version int) (*models.Tender, error) {
+func (repo *TenderRepository) RollbackTender(ctx context.Context, tenderID uuid.UUID, version int) (*models.Tender, error) {
 	query := `
-		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username
+		SELECT id, title, description, status, organization_id, version, created_at, updated_at, service_type, creator_username, submission_deadline, decision_deadline, budget_min, budget_max, currency, sealed, bids_opened_at, kind, min_decrement, round_duration, sniping_extension, auction_starts_at, visibility
 		FROM tender
 		WHERE id = $1 AND version = $2
 	`
 
 	var tenderRepo models.Tender
-	err := repo.DB.Get(&tenderRepo, query, tenderID, version)
+	ctx, span := startSpan(ctx, "TenderRepository.RollbackTender", query)
+	err := repo.DB.GetContext(ctx, &tenderRepo, query, tenderID, version)
+	endSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to rollback tender")
 	}
@@ -181,7 +557,7 @@ func (repo *TenderRepository) RollbackTender(tenderID uuid.UUID, version int) (*
 	return &tenderRepo, nil
 }
 
-func (repo *TenderRepository) GetTenderStatus(tenderID uuid.UUID) (string, error) {
+func (repo *TenderRepository) GetTenderStatus(ctx context.Context, tenderID uuid.UUID) (string, error) {
 	var status string
 	query := `
 		SELECT status
@@ -189,10 +565,28 @@ func (repo *TenderRepository) GetTenderStatus(tenderID uuid.UUID) (string, error
 		WHERE id = $1
 	`
 
-	err := repo.DB.Get(&status, query, tenderID)
+	ctx, span := startSpan(ctx, "TenderRepository.GetTenderStatus", query)
+	err := repo.DB.GetContext(ctx, &status, query, tenderID)
+	endSpan(span, err)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get tender status")
 	}
 
 	return status, nil
 }
+
+func (repo *TenderRepository) CanViewTender(ctx context.Context, tenderID uuid.UUID, username string) (bool, error) {
+	query := `
+		SELECT COALESCE(tender_visible_to($1, $2), FALSE)
+	`
+
+	var visible bool
+	ctx, span := startSpan(ctx, "TenderRepository.CanViewTender", query)
+	err := repo.DB.GetContext(ctx, &visible, query, tenderID, username)
+	endSpan(span, err)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check tender visibility")
+	}
+
+	return visible, nil
+}
@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+type WebhookRepository struct {
+	DB *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) _interface.WebhookRepository {
+	return &WebhookRepository{
+		DB: db,
+	}
+}
+
+func (repo *WebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription, createdByUsername string) error {
+	query := `
+		INSERT INTO webhook_subscription (id, organization_id, url, secret, event_types, created_by, created_at)
+		VALUES ($1, $2, $3, $4, string_to_array($5, ','), (SELECT id FROM employee WHERE username = $6), $7)
+		RETURNING created_by
+	`
+
+	subscription.ID = uuid.New()
+	subscription.CreatedAt = time.Now()
+
+	ctx, span := startSpan(ctx, "WebhookRepository.CreateSubscription", query)
+	err := repo.DB.GetContext(ctx, &subscription.CreatedBy, query, subscription.ID, subscription.OrganizationID, subscription.URL,
+		subscription.Secret, subscription.EventTypes.String(), createdByUsername, subscription.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook subscription")
+	}
+
+	return nil
+}
+
+func (repo *WebhookRepository) GetSubscriptions(ctx context.Context, orgID uuid.UUID) ([]models.WebhookSubscription, error) {
+	query := `
+		SELECT id, organization_id, url, array_to_string(event_types, ',') AS event_types, created_by, created_at
+		FROM webhook_subscription
+		WHERE organization_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+
+	subscriptions := []models.WebhookSubscription{}
+	ctx, span := startSpan(ctx, "WebhookRepository.GetSubscriptions", query)
+	err := repo.DB.SelectContext(ctx, &subscriptions, query, orgID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook subscriptions")
+	}
+
+	return subscriptions, nil
+}
+
+func (repo *WebhookRepository) GetSubscription(ctx context.Context, orgID uuid.UUID, subscriptionID uuid.UUID) (*models.WebhookSubscription, error) {
+	query := `
+		SELECT id, organization_id, url, array_to_string(event_types, ',') AS event_types, created_by, created_at
+		FROM webhook_subscription
+		WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL
+	`
+
+	var subscription models.WebhookSubscription
+	ctx, span := startSpan(ctx, "WebhookRepository.GetSubscription", query)
+	err := repo.DB.GetContext(ctx, &subscription, query, orgID, subscriptionID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook subscription")
+	}
+
+	return &subscription, nil
+}
+
+// DeleteSubscription stops deliveries to the subscription but keeps its delivery history.
+func (repo *WebhookRepository) DeleteSubscription(ctx context.Context, orgID uuid.UUID, subscriptionID uuid.UUID) error {
+	query := `
+		UPDATE webhook_subscription
+		SET deleted_at = $3
+		WHERE organization_id = $1 AND id = $2 AND deleted_at IS NULL
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.DeleteSubscription", query)
+	result, err := repo.DB.ExecContext(ctx, query, orgID, subscriptionID, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete webhook subscription")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete webhook subscription")
+	}
+
+	if affected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "failed to delete webhook subscription")
+	}
+
+	return nil
+}
+
+func (repo *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, status models.DeliveryStatus, limit int, offset int) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT id, subscription_id, event_id, event_type, payload::text AS payload, status, attempts, next_attempt_at,
+			last_attempt_at, response_status, last_error, delivered_at, created_at
+		FROM webhook_delivery
+		WHERE subscription_id = $1 AND ($2::text = '' OR status = $2::text)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	deliveries := []models.WebhookDelivery{}
+	ctx, span := startSpan(ctx, "WebhookRepository.GetDeliveries", query)
+	err := repo.DB.SelectContext(ctx, &deliveries, query, subscriptionID, string(status), limit, offset)
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// EnqueueDeliveries delivers tender events to the tender's organization and to every organization
+// that bid on the tender; proposal events only go to the tender's organization and the bidder.
+func (repo *WebhookRepository) EnqueueDeliveries(ctx context.Context, event models.DomainEvent) (int, error) {
+	tenderID, proposalID, err := eventTarget(ctx, repo.DB, event)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO webhook_delivery (id, subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		SELECT uuid_generate_v4(), s.id, $1, $2::text, $3, 'PENDING', $6, $6
+		FROM webhook_subscription s
+		WHERE s.deleted_at IS NULL AND $2::text = ANY(s.event_types) AND s.organization_id IN (
+			SELECT t.organization_id FROM tender t WHERE t.id = $4
+			UNION
+			SELECT p.organization_id FROM proposal p WHERE p.tender_id = $4 AND ($5::uuid IS NULL OR p.id = $5::uuid)
+		)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.EnqueueDeliveries", query)
+	result, err := repo.DB.ExecContext(ctx, query, event.ID, string(event.Type), string(event.Payload), tenderID, proposalID, time.Now())
+	endSpan(span, err)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to enqueue webhook deliveries")
+	}
+
+	enqueued, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to enqueue webhook deliveries")
+	}
+
+	return int(enqueued), nil
+}
+
+func (repo *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.DueDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_delivery d
+			JOIN webhook_subscription s ON d.subscription_id = s.id
+			WHERE d.status = 'PENDING' AND d.next_attempt_at <= $1 AND s.deleted_at IS NULL
+			ORDER BY d.next_attempt_at
+			LIMIT $2
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_delivery d
+		SET next_attempt_at = $3
+		FROM due, webhook_subscription s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload::text AS payload, d.status, d.attempts,
+			d.next_attempt_at, d.last_attempt_at, d.response_status, d.last_error, d.delivered_at, d.created_at, s.url, s.secret
+	`
+
+	now := time.Now()
+	deliveries := []models.DueDelivery{}
+	ctx, span := startSpan(ctx, "WebhookRepository.ClaimDueDeliveries", query)
+	err := repo.DB.SelectContext(ctx, &deliveries, query, now, limit, now.Add(lease))
+	endSpan(span, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+func (repo *WebhookRepository) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, status models.DeliveryStatus, attempt models.DeliveryAttempt, nextAttemptAt *time.Time) error {
+	query := `
+		UPDATE webhook_delivery
+		SET status = $2, attempts = attempts + 1, last_attempt_at = $3, response_status = $4, last_error = $5,
+			next_attempt_at = $6, delivered_at = $7
+		WHERE id = $1
+	`
+
+	var lastError *string
+	if attempt.Err != nil {
+		message := attempt.Err.Error()
+		lastError = &message
+	}
+
+	now := time.Now()
+	var deliveredAt *time.Time
+	if status == models.DeliveryDelivered {
+		deliveredAt = &now
+	}
+
+	ctx, span := startSpan(ctx, "WebhookRepository.RecordAttempt", query)
+	_, err := repo.DB.ExecContext(ctx, query, deliveryID, string(status), now, attempt.ResponseStatus, lastError, nextAttemptAt, deliveredAt)
+	endSpan(span, err)
+	if err != nil {
+		return errors.Wrap(err, "failed to record webhook delivery attempt")
+	}
+
+	return nil
+}
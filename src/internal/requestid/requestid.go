@@ -0,0 +1,17 @@
+package requestid
+
+import (
+	"context"
+)
+
+type requestIDKey struct{}
+
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// FromContext returns the request ID of the current request, or an empty string.
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
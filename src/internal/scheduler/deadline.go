@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"avito_2024/src/internal/audit"
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/sealing"
+)
+
+// SystemActor is recorded in the audit log for changes made by the scheduler.
+const SystemActor = "system"
+
+// DeadlineScheduler periodically opens the bids of sealed tenders whose submission deadline has
+// passed and closes published tenders whose decision deadline has passed.
+type DeadlineScheduler struct {
+	Tenders   _interface.TenderService
+	Opener    *sealing.Opener
+	BatchSize int
+	Interval  time.Duration
+}
+
+func NewDeadlineScheduler(tenders _interface.TenderService, opener *sealing.Opener, batchSize int, interval time.Duration) *DeadlineScheduler {
+	return &DeadlineScheduler{
+		Tenders:   tenders,
+		Opener:    opener,
+		BatchSize: batchSize,
+		Interval:  interval,
+	}
+}
+
+// Run closes expired tenders until the context is canceled.
+func (s *DeadlineScheduler) Run(ctx context.Context) {
+	ctx = audit.WithActor(ctx, SystemActor)
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		opened, err := s.Opener.OpenDue(ctx, time.Now(), s.BatchSize)
+		if err != nil && ctx.Err() == nil {
+			log.Println("Error with opening sealed bids.", err)
+		}
+
+		if opened > 0 {
+			log.Printf("Opened bids of %d sealed tenders", opened)
+		}
+
+		closed, err := s.Tenders.CloseExpiredTenders(ctx, time.Now(), s.BatchSize)
+		if err != nil && ctx.Err() == nil {
+			log.Println("Error with closing expired tenders.", err)
+		}
+
+		if closed > 0 {
+			log.Printf("Closed %d expired tenders", closed)
+		}
+
+		if err == nil && closed == s.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
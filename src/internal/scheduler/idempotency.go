@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"avito_2024/src/internal/domain/interface"
+)
+
+// IdempotencyCleaner periodically deletes idempotency keys whose TTL has passed.
+type IdempotencyCleaner struct {
+	Keys      _interface.IdempotencyRepository
+	BatchSize int
+	Interval  time.Duration
+}
+
+func NewIdempotencyCleaner(keys _interface.IdempotencyRepository, batchSize int, interval time.Duration) *IdempotencyCleaner {
+	return &IdempotencyCleaner{
+		Keys:      keys,
+		BatchSize: batchSize,
+		Interval:  interval,
+	}
+}
+
+// Run deletes expired keys until the context is canceled.
+func (c *IdempotencyCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := c.Keys.DeleteExpiredIdempotencyKeys(ctx, time.Now(), c.BatchSize)
+		if err != nil && ctx.Err() == nil {
+			log.Println("Error with deleting expired idempotency keys.", err)
+		}
+
+		if err == nil && deleted == c.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package sealing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/interface"
+	"avito_2024/src/internal/domain/models"
+)
+
+// Opener decrypts the bids of sealed tenders and stores them in the clear.
+type Opener struct {
+	Tenders   _interface.TenderService
+	Proposals _interface.ProposalRepository
+	Sealer    *Sealer
+}
+
+func NewOpener(tenders _interface.TenderService, proposals _interface.ProposalRepository, sealer *Sealer) *Opener {
+	return &Opener{
+		Tenders:   tenders,
+		Proposals: proposals,
+		Sealer:    sealer,
+	}
+}
+
+// OpenBids opens the bids of the sealed tender. It returns models.ErrInvalidState if the tender is
+// not sealed or its bids are already open.
+func (o *Opener) OpenBids(ctx context.Context, tenderID uuid.UUID) error {
+	proposals, err := o.Proposals.GetSealedProposals(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	for i := range proposals {
+		if err = o.Sealer.Open(&proposals[i]); err != nil {
+			return errors.Wrapf(err, "failed to open proposal %s", proposals[i].ID)
+		}
+	}
+
+	return o.Tenders.OpenBids(ctx, tenderID, proposals)
+}
+
+// OpenDue opens the bids of up to limit sealed tenders whose submission deadline has passed.
+// Tenders opened concurrently by another service instance are skipped; a failure to open one tender
+// does not hold up the others and the first failure is returned.
+func (o *Opener) OpenDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	tenders, err := o.Tenders.GetTendersToOpen(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	opened := 0
+	var firstErr error
+	for _, tender := range tenders {
+		err = o.OpenBids(ctx, tender.ID)
+		if errors.Cause(err) == models.ErrInvalidState {
+			continue
+		}
+
+		if err != nil {
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "failed to open bids of tender %s", tender.ID)
+			}
+			continue
+		}
+
+		opened++
+	}
+
+	return opened, firstErr
+}
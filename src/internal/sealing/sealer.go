@@ -0,0 +1,122 @@
+package sealing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+// KeySize is the size of the AES-256 key bids are encrypted with.
+const KeySize = 32
+
+// Sealer encrypts the contents of bids on sealed tenders with AES-256-GCM. The tender ID is
+// authenticated with the content, so a sealed bid cannot be moved to another tender.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+func NewSealer(key []byte) (*Sealer, error) {
+	if len(key) != KeySize {
+		return nil, errors.Errorf("bid encryption key must be %d bytes", KeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+
+	return &Sealer{aead: aead}, nil
+}
+
+// NewSealerFromBase64 creates a sealer with a base64-encoded key.
+func NewSealerFromBase64(key string) (*Sealer, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode bid encryption key")
+	}
+
+	return NewSealer(decoded)
+}
+
+// Seal encrypts the content of the proposal into SealedContent and clears the plain fields.
+func (s *Sealer) Seal(proposal *models.Proposal) error {
+	plaintext, err := json.Marshal(proposal.Content())
+	if err != nil {
+		return errors.Wrap(err, "failed to encode proposal content")
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+
+	proposal.SealedContent = s.aead.Seal(nonce, nonce, plaintext, proposal.TenderID[:])
+	proposal.Sealed = true
+	proposal.SetContent(models.ProposalContent{})
+
+	return nil
+}
+
+// Open decrypts SealedContent into the plain fields of the proposal. Proposals that are not sealed are left as is.
+func (s *Sealer) Open(proposal *models.Proposal) error {
+	if proposal.SealedContent == nil {
+		return nil
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(proposal.SealedContent) < nonceSize {
+		return errors.New("sealed proposal content is too short")
+	}
+
+	nonce, ciphertext := proposal.SealedContent[:nonceSize], proposal.SealedContent[nonceSize:]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, proposal.TenderID[:])
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt proposal content")
+	}
+
+	var content models.ProposalContent
+	if err = json.Unmarshal(plaintext, &content); err != nil {
+		return errors.Wrap(err, "failed to decode proposal content")
+	}
+
+	proposal.SetContent(content)
+
+	return nil
+}
+
+// SealBlob encrypts the contents of a file attached to a bid on the tender.
+func (s *Sealer) SealBlob(data []byte, tenderID uuid.UUID) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	return s.aead.Seal(nonce, nonce, data, tenderID[:]), nil
+}
+
+// OpenBlob decrypts the contents of a file sealed with SealBlob.
+func (s *Sealer) OpenBlob(sealed []byte, tenderID uuid.UUID) ([]byte, error) {
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("sealed file is too short")
+	}
+
+	data, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], tenderID[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt file")
+	}
+
+	return data, nil
+}
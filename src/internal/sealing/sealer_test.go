@@ -0,0 +1,140 @@
+package sealing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"avito_2024/src/internal/domain/models"
+)
+
+func newTestSealer(t *testing.T) *Sealer {
+	t.Helper()
+
+	sealer, err := NewSealer(bytes.Repeat([]byte{7}, KeySize))
+	if err != nil {
+		t.Fatalf("NewSealer() error = %v", err)
+	}
+
+	return sealer
+}
+
+func TestNewSealerRejectsKeySize(t *testing.T) {
+	for _, size := range []int{0, 16, 31, 33} {
+		if _, err := NewSealer(make([]byte, size)); err == nil {
+			t.Errorf("NewSealer() with a %d-byte key succeeded, want an error", size)
+		}
+	}
+
+	if _, err := NewSealerFromBase64("not base64!"); err == nil {
+		t.Error("NewSealerFromBase64() with an invalid key succeeded, want an error")
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	sealer := newTestSealer(t)
+
+	price := models.Amount(250000)
+	proposal := models.Proposal{
+		TenderID:    uuid.New(),
+		Title:       "Delivery",
+		Description: "Two trucks a week",
+		Price:       &price,
+		Currency:    "RUB",
+	}
+	want := proposal.Content()
+
+	if err := sealer.Seal(&proposal); err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	if !proposal.Sealed || proposal.SealedContent == nil {
+		t.Fatal("Seal() did not mark the proposal sealed")
+	}
+
+	if proposal.Title != "" || proposal.Description != "" || proposal.Price != nil || proposal.Currency != "" {
+		t.Errorf("Seal() left plain content %+v", proposal.Content())
+	}
+
+	if bytes.Contains(proposal.SealedContent, []byte(want.Title)) {
+		t.Error("sealed content contains the plain title")
+	}
+
+	if err := sealer.Open(&proposal); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	got := proposal.Content()
+	if got.Title != want.Title || got.Description != want.Description || got.Currency != want.Currency || got.Price == nil || *got.Price != price {
+		t.Errorf("Open() content = %+v, want %+v", got, want)
+	}
+}
+
+func TestOpenRejectsOtherTender(t *testing.T) {
+	sealer := newTestSealer(t)
+
+	proposal := models.Proposal{TenderID: uuid.New(), Title: "Delivery"}
+	if err := sealer.Seal(&proposal); err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	proposal.TenderID = uuid.New()
+	if err := sealer.Open(&proposal); err == nil {
+		t.Error("Open() of a bid moved to another tender succeeded, want an error")
+	}
+}
+
+func TestOpenRejectsTamperedContent(t *testing.T) {
+	sealer := newTestSealer(t)
+
+	proposal := models.Proposal{TenderID: uuid.New(), Title: "Delivery"}
+	if err := sealer.Seal(&proposal); err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	proposal.SealedContent[len(proposal.SealedContent)-1] ^= 1
+	if err := sealer.Open(&proposal); err == nil {
+		t.Error("Open() of tampered content succeeded, want an error")
+	}
+
+	proposal.SealedContent = []byte{1, 2, 3}
+	if err := sealer.Open(&proposal); err == nil {
+		t.Error("Open() of short content succeeded, want an error")
+	}
+}
+
+func TestOpenIgnoresUnsealed(t *testing.T) {
+	proposal := models.Proposal{TenderID: uuid.New(), Title: "Delivery"}
+	if err := newTestSealer(t).Open(&proposal); err != nil || proposal.Title != "Delivery" {
+		t.Errorf("Open() of an unsealed bid = %q, %v, want it unchanged", proposal.Title, err)
+	}
+}
+
+func TestSealOpenBlob(t *testing.T) {
+	sealer := newTestSealer(t)
+	tenderID := uuid.New()
+	data := []byte("%PDF-1.7 specification")
+
+	sealed, err := sealer.SealBlob(data, tenderID)
+	if err != nil {
+		t.Fatalf("SealBlob() error = %v", err)
+	}
+
+	if bytes.Contains(sealed, data) {
+		t.Error("sealed file contains the plain data")
+	}
+
+	opened, err := sealer.OpenBlob(sealed, tenderID)
+	if err != nil || !bytes.Equal(opened, data) {
+		t.Errorf("OpenBlob() = %q, %v, want %q", opened, err, data)
+	}
+
+	if _, err = sealer.OpenBlob(sealed, uuid.New()); err == nil {
+		t.Error("OpenBlob() with another tender ID succeeded, want an error")
+	}
+
+	if _, err = sealer.OpenBlob(sealed[:4], tenderID); err == nil {
+		t.Error("OpenBlob() of a short file succeeded, want an error")
+	}
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// LocalStorage keeps blobs as files under a directory. A blob is written to a temporary file and
+// renamed into place, so readers never see a partial blob.
+type LocalStorage struct {
+	Dir string
+}
+
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errors.Wrap(err, "failed to create storage directory")
+	}
+
+	return &LocalStorage{Dir: dir}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return errors.Wrap(err, "failed to create blob directory")
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create blob file")
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write blob")
+	}
+
+	if written != size {
+		return errors.Errorf("blob size is %d bytes, expected %d", written, size)
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		return errors.Wrap(err, "failed to store blob")
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open blob")
+	}
+
+	return file, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete blob")
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", errors.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
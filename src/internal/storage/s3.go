@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// unsignedPayload tells S3 that the request body is not covered by the signature, so uploads
+// can be streamed without hashing the body first.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+const (
+	amzDateFormat = "20060102T150405Z"
+	signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+)
+
+type S3Config struct {
+	// Endpoint is the base URL of the S3-compatible service, e.g. http://localhost:9000.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Storage keeps blobs in a bucket of an S3-compatible service (AWS S3, MinIO). Objects are
+// addressed path-style and requests are signed with AWS Signature Version 4.
+type S3Storage struct {
+	Config S3Config
+	Client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Storage creates the storage. A nil client is replaced with one with a minute timeout.
+func NewS3Storage(config S3Config, client *http.Client) *S3Storage {
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute}
+	}
+
+	return &S3Storage{Config: config, Client: client, now: time.Now}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	request, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+
+	request.ContentLength = size
+	if size == 0 {
+		request.Body = http.NoBody
+	}
+	request.Header.Set("Content-Type", contentType)
+	s.sign(request)
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to upload blob")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return s3Error("failed to upload blob", response)
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(request)
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download blob")
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, ErrNotFound
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, s3Error("failed to download blob", response)
+	}
+
+	return response.Body, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	request, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(request)
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete blob")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return s3Error("failed to delete blob", response)
+	}
+
+	return nil
+}
+
+func (s *S3Storage) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if !validKey(key) {
+		return nil, errors.Errorf("invalid blob key %q", key)
+	}
+
+	endpoint := strings.TrimSuffix(s.Config.Endpoint, "/") + escapePath("/"+s.Config.Bucket+"/"+key)
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create storage request")
+	}
+
+	return request, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to the request.
+func (s *S3Storage) sign(request *http.Request) {
+	amzDate := s.now().UTC().Format(amzDateFormat)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	scope, signature := signatureV4(request, amzDate, s.Config.Region, s.Config.SecretKey)
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.Config.AccessKey, scope, signedHeaders, signature))
+}
+
+// signatureV4 returns the credential scope and the signature of a request that signs signedHeaders
+// and leaves the payload unsigned.
+func signatureV4(request *http.Request, amzDate string, region string, secretKey string) (string, string) {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		"host:" + host,
+		"x-amz-content-sha256:" + unsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	date := amzDate[:8]
+	scope := date + "/" + region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return scope, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapePath percent-encodes every byte of the path except the unreserved characters and slashes,
+// as the canonical request of Signature Version 4 requires.
+func escapePath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			escaped.WriteByte(c)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+
+	return escaped.String()
+}
+
+func s3Error(message string, response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return errors.Errorf("%s: %s: %s", message, response.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newFakeS3Storage(t *testing.T, secretKey string) (*S3Storage, *FakeS3) {
+	t.Helper()
+
+	fake := NewFakeS3("access", "secret", "attachments")
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	blobs := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "attachments", AccessKey: "access", SecretKey: secretKey}, server.Client())
+
+	return blobs, fake
+}
+
+func TestS3StoragePutGetDelete(t *testing.T) {
+	blobs, fake := newFakeS3Storage(t, "secret")
+	ctx := context.Background()
+	key := "tenders/1/report 2024.pdf"
+	content := "%PDF-1.4 sealed bid"
+
+	if err := blobs.Put(ctx, key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if fake.Objects("attachments") != 1 {
+		t.Fatalf("bucket has %d objects, want 1", fake.Objects("attachments"))
+	}
+
+	body, err := blobs.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatalf("reading blob: %v", err)
+	}
+
+	if string(data) != content {
+		t.Errorf("Get() = %q, want %q", data, content)
+	}
+
+	if err = blobs.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = blobs.Get(ctx, key); err != ErrNotFound {
+		t.Errorf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+
+	if err = blobs.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of a missing blob error = %v, want nil", err)
+	}
+}
+
+func TestS3StoragePutEmptyBlob(t *testing.T) {
+	blobs, fake := newFakeS3Storage(t, "secret")
+
+	if err := blobs.Put(context.Background(), "bids/1/empty.txt", strings.NewReader(""), 0, "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if fake.Objects("attachments") != 1 {
+		t.Errorf("bucket has %d objects, want 1", fake.Objects("attachments"))
+	}
+}
+
+func TestS3StorageRejectedWithWrongSecret(t *testing.T) {
+	blobs, fake := newFakeS3Storage(t, "wrong")
+
+	err := blobs.Put(context.Background(), "tenders/1/file.txt", strings.NewReader("data"), 4, "text/plain")
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Fatalf("Put() error = %v, want SignatureDoesNotMatch", err)
+	}
+
+	if fake.Objects("attachments") != 0 {
+		t.Errorf("bucket has %d objects after a rejected upload", fake.Objects("attachments"))
+	}
+}
+
+func TestS3StorageRejectsInvalidKey(t *testing.T) {
+	blobs, _ := newFakeS3Storage(t, "secret")
+
+	for _, key := range []string{"", "../etc/passwd", "tenders//file", "tenders/./file"} {
+		if err := blobs.Delete(context.Background(), key); err == nil {
+			t.Errorf("Delete(%q) error = nil, want an invalid key error", key)
+		}
+	}
+}
+
+// The expected signature is computed independently by the algorithm of the AWS Signature Version 4
+// documentation for a request with an unsigned payload.
+func TestSignatureV4(t *testing.T) {
+	request, err := http.NewRequest(http.MethodPut, "http://localhost:9000"+escapePath("/attachments/tenders/report 2024.pdf"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope, signature := signatureV4(request, "20240915T100000Z", "us-east-1", "secret")
+
+	if scope != "20240915/us-east-1/s3/aws4_request" {
+		t.Errorf("scope = %q", scope)
+	}
+
+	if want := "b56cba236f192289fca8668eb67ce1e75eb8dc20a8ce04bda456170421563b36"; signature != want {
+		t.Errorf("signature = %s, want %s", signature, want)
+	}
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Storage backends accepted in STORAGE_BACKEND.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound is returned when no blob is stored under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStorage stores the contents of attachments under keys chosen by the caller. Keys are
+// slash-separated paths of unreserved characters.
+type BlobStorage interface {
+	// Put stores size bytes read from body under the key, replacing any previous blob.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get opens the blob stored under the key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under the key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorageFromEnv creates the storage chosen by STORAGE_BACKEND, local by default.
+func NewStorageFromEnv() (BlobStorage, error) {
+	kind := os.Getenv("STORAGE_BACKEND")
+
+	switch strings.ToLower(kind) {
+	case BackendLocal, "":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "data/attachments"
+		}
+
+		return NewLocalStorage(dir)
+	case BackendS3:
+		config := S3Config{
+			Endpoint:  os.Getenv("STORAGE_S3_ENDPOINT"),
+			Region:    os.Getenv("STORAGE_S3_REGION"),
+			Bucket:    os.Getenv("STORAGE_S3_BUCKET"),
+			AccessKey: os.Getenv("STORAGE_S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("STORAGE_S3_SECRET_KEY"),
+		}
+
+		if config.Endpoint == "" || config.Bucket == "" {
+			return nil, errors.New("STORAGE_S3_ENDPOINT and STORAGE_S3_BUCKET are not set")
+		}
+
+		return NewS3Storage(config, nil), nil
+	default:
+		return nil, errors.Errorf("unknown storage backend %q", kind)
+	}
+}
+
+// validKey reports whether the key is a relative slash-separated path without empty, "." or ".." segments.
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}